@@ -0,0 +1,290 @@
+// Command expect-snapshots reviews snapshots which did not match in the last test run.
+//
+// It scans the given folders (default .) for .current files written by ToBeSnapshot and
+// .current.png files written by ToBeSnapshotImage, shows the difference to the snapshot
+// and asks whether to accept the current version, reject it or skip it.
+//
+//	expect-snapshots [--accept-all] [folder...]
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/akabio/expect/internal/imageext"
+	"github.com/akabio/expect/internal/textdiff"
+)
+
+// pending is a snapshot with a current version waiting for review.
+type pending struct {
+	snapshot string
+	current  string
+	// artifacts are additional files like the diff image which are removed after the review
+	artifacts []string
+	image     bool
+}
+
+func main() {
+	acceptAll := flag.Bool("accept-all", false, "accept all current versions without asking")
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: expect-snapshots [--accept-all] [folder...]\n")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	folders := flag.Args()
+	if len(folders) == 0 {
+		folders = []string{"."}
+	}
+
+	r := &reviewer{
+		in:        bufio.NewReader(os.Stdin),
+		out:       os.Stdout,
+		acceptAll: *acceptAll,
+		open:      openFiles,
+	}
+
+	err := r.reviewAll(folders)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// findPending returns all current versions in the folder sorted by path.
+func findPending(root string) ([]pending, error) {
+	found := []pending{}
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path != root && (d.Name() == "vendor" || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if strings.HasSuffix(path, ".current") {
+			found = append(found, pending{snapshot: strings.TrimSuffix(path, ".current"), current: path})
+			return nil
+		}
+
+		for _, ext := range imageext.Extensions {
+			if strings.HasSuffix(path, ".current"+ext) {
+				base := strings.TrimSuffix(path, ".current"+ext)
+				found = append(found, pending{
+					snapshot:  base + ext,
+					current:   path,
+					artifacts: []string{base + ".diff" + ext, base + ".compare" + ext},
+					image:     true,
+				})
+			}
+		}
+
+		return nil
+	})
+
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].current < found[j].current
+	})
+
+	return found, err
+}
+
+// reviewer asks for each pending snapshot what to do with it.
+type reviewer struct {
+	in        *bufio.Reader
+	out       io.Writer
+	acceptAll bool
+	// open shows the images in an external viewer
+	open func(paths ...string) error
+
+	accepted, rejected, skipped int
+}
+
+// reviewAll reviews the pending snapshots of all folders and prints a summary. When the input
+// ends the remaining snapshots stay pending.
+func (r *reviewer) reviewAll(folders []string) error {
+review:
+	for _, folder := range folders {
+		found, err := findPending(folder)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range found {
+			err := r.review(p)
+			if err == io.EOF {
+				fmt.Fprintln(r.out)
+				break review
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	fmt.Fprintf(r.out, "accepted %v, rejected %v, skipped %v\n", r.accepted, r.rejected, r.skipped)
+
+	return nil
+}
+
+func (r *reviewer) review(p pending) error {
+	fmt.Fprintf(r.out, "\n%v\n", p.snapshot)
+
+	if r.acceptAll {
+		return r.accept(p)
+	}
+
+	err := r.showDiff(p)
+	if err != nil {
+		return err
+	}
+
+	for {
+		options := "[a]ccept, [r]eject, [s]kip"
+		if p.image {
+			options += ", [o]pen"
+		}
+
+		fmt.Fprintf(r.out, "%v? ", options)
+
+		answer, err := r.in.ReadString('\n')
+		if err != nil && (err != io.EOF || answer == "") {
+			return err
+		}
+
+		switch strings.TrimSpace(answer) {
+		case "a":
+			return r.accept(p)
+		case "r":
+			return r.reject(p)
+		case "s":
+			r.skipped++
+			return nil
+		case "o":
+			if p.image {
+				err := r.open(existing(append([]string{p.snapshot, p.current}, p.artifacts...))...)
+				if err != nil {
+					fmt.Fprintf(r.out, "failed to open images, %v\n", err)
+				}
+			}
+		}
+	}
+}
+
+// showDiff prints the text diff or the diff images of a pending snapshot.
+func (r *reviewer) showDiff(p pending) error {
+	if p.image {
+		for _, a := range existing(p.artifacts) {
+			fmt.Fprintf(r.out, "diff image: %v\n", a)
+		}
+
+		return nil
+	}
+
+	current, err := os.ReadFile(p.current)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := os.ReadFile(p.snapshot)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if textdiff.IsBinary(snapshot) || textdiff.IsBinary(current) {
+		fmt.Fprintln(r.out, textdiff.Binary(snapshot, current, p.snapshot, p.current))
+		return nil
+	}
+
+	fmt.Fprintln(r.out, textdiff.Unified(string(snapshot), string(current), p.snapshot, p.current, 3, 0))
+
+	return nil
+}
+
+// accept moves the current version over the snapshot.
+func (r *reviewer) accept(p pending) error {
+	err := os.Rename(p.current, p.snapshot)
+	if err != nil {
+		return err
+	}
+
+	removeAll(p.artifacts)
+	r.accepted++
+	fmt.Fprintf(r.out, "accepted %v\n", p.current)
+
+	return nil
+}
+
+// reject deletes the current version.
+func (r *reviewer) reject(p pending) error {
+	err := os.Remove(p.current)
+	if err != nil {
+		return err
+	}
+
+	removeAll(p.artifacts)
+	r.rejected++
+	fmt.Fprintf(r.out, "rejected %v\n", p.current)
+
+	return nil
+}
+
+func removeAll(paths []string) {
+	for _, p := range paths {
+		os.Remove(p)
+	}
+}
+
+// existing filters the paths which exist.
+func existing(paths []string) []string {
+	found := []string{}
+
+	for _, p := range paths {
+		if _, err := os.Stat(p); err == nil {
+			found = append(found, p)
+		}
+	}
+
+	return found
+}
+
+// openFiles opens the files with the default application of the system.
+func openFiles(paths ...string) error {
+	for _, p := range paths {
+		var cmd *exec.Cmd
+
+		switch runtime.GOOS {
+		case "darwin":
+			cmd = exec.Command("open", p)
+		case "windows":
+			cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", p)
+		default:
+			cmd = exec.Command("xdg-open", p)
+		}
+
+		err := cmd.Start()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/akabio/expect"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+
+		err := os.MkdirAll(filepath.Dir(path), 0o755)
+		expect.Error(t, err).ToBe(nil)
+
+		err = os.WriteFile(path, []byte(content), 0o644)
+		expect.Error(t, err).ToBe(nil)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "<missing>"
+	}
+
+	expect.Error(t, err).ToBe(nil)
+
+	return string(data)
+}
+
+func TestReview(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a.txt":             "old a\n",
+		"a.txt.current":     "new a\n",
+		"b.txt":             "old b\n",
+		"b.txt.current":     "new b\n",
+		"c.txt":             "old c\n",
+		"c.txt.current":     "new c\n",
+		"img/d.png":         "old d",
+		"img/d.current.png": "new d",
+		"img/d.diff.png":    "diff d",
+		".git/e.current":    "ignored",
+	})
+
+	found, err := findPending(dir)
+	expect.Error(t, err).ToBe(nil)
+	expect.Value(t, "pending", found).ToCount(4)
+
+	out := bytes.NewBuffer(nil)
+	opened := []string{}
+	r := &reviewer{
+		in:  bufio.NewReader(strings.NewReader("a\nr\nx\ns\no\na\n")),
+		out: out,
+		open: func(paths ...string) error {
+			opened = append(opened, paths...)
+			return nil
+		},
+	}
+
+	for _, p := range found {
+		err := r.review(p)
+		expect.Error(t, err).ToBe(nil)
+	}
+
+	expect.Value(t, "a", readFile(t, filepath.Join(dir, "a.txt"))).ToBe("new a\n")
+	expect.Value(t, "a current", readFile(t, filepath.Join(dir, "a.txt.current"))).ToBe("<missing>")
+	expect.Value(t, "b", readFile(t, filepath.Join(dir, "b.txt"))).ToBe("old b\n")
+	expect.Value(t, "b current", readFile(t, filepath.Join(dir, "b.txt.current"))).ToBe("<missing>")
+	expect.Value(t, "c", readFile(t, filepath.Join(dir, "c.txt"))).ToBe("old c\n")
+	expect.Value(t, "c current", readFile(t, filepath.Join(dir, "c.txt.current"))).ToBe("new c\n")
+	expect.Value(t, "d", readFile(t, filepath.Join(dir, "img/d.png"))).ToBe("new d")
+	expect.Value(t, "d diff", readFile(t, filepath.Join(dir, "img/d.diff.png"))).ToBe("<missing>")
+
+	expect.Value(t, "opened", opened).ToCount(3)
+	expect.Value(t, "output", out.String()).ToContain("-old a\n+new a")
+	expect.Value(t, "counts", []int{r.accepted, r.rejected, r.skipped}).ToBe([]int{2, 1, 1})
+}
+
+func TestReviewAcceptAll(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a.txt.current":         "new a\n",
+		"sub/b.tiff":            "old b",
+		"sub/b.current.tiff":    "new b",
+		"sub/b.compare.tiff":    "compare b",
+		"vendor/c.json.current": "ignored",
+	})
+
+	found, err := findPending(dir)
+	expect.Error(t, err).ToBe(nil)
+
+	r := &reviewer{out: bytes.NewBuffer(nil), acceptAll: true}
+
+	for _, p := range found {
+		err := r.review(p)
+		expect.Error(t, err).ToBe(nil)
+	}
+
+	expect.Value(t, "accepted", r.accepted).ToBe(2)
+	expect.Value(t, "a", readFile(t, filepath.Join(dir, "a.txt"))).ToBe("new a\n")
+	expect.Value(t, "b", readFile(t, filepath.Join(dir, "sub/b.tiff"))).ToBe("new b")
+	expect.Value(t, "b compare", readFile(t, filepath.Join(dir, "sub/b.compare.tiff"))).ToBe("<missing>")
+	expect.Value(t, "vendor", readFile(t, filepath.Join(dir, "vendor/c.json.current"))).ToBe("ignored")
+}
+
+func TestReviewEndOfInput(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a.txt.current": "new a\n",
+		"b.txt.current": "new b\n",
+	})
+
+	out := bytes.NewBuffer(nil)
+	r := &reviewer{in: bufio.NewReader(strings.NewReader("a\n")), out: out}
+
+	err := r.reviewAll([]string{dir})
+	expect.Error(t, err).ToBe(nil)
+
+	expect.Value(t, "output", out.String()).ToHaveSuffix("accepted 1, rejected 0, skipped 0\n")
+	expect.Value(t, "b current", readFile(t, filepath.Join(dir, "b.txt.current"))).ToBe("new b\n")
+}
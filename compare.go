@@ -0,0 +1,314 @@
+package expect
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultMaxDifferences is used when Expect.MaxDifferences is not set.
+const defaultMaxDifferences = 10
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	jsonIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+)
+
+// difference is a single mismatch found by compare.
+type difference struct {
+	path string
+	msg  string
+}
+
+func (d difference) String() string {
+	return d.path + ": " + d.msg
+}
+
+// comparer walks two values and collects the differences by path.
+type comparer struct {
+	max   int
+	diffs []difference
+	// more counts the differences that were found after max was reached
+	more int
+	// visited pointer pairs, protects against cycles
+	visited map[[2]uintptr]bool
+	// jsonPath formats map keys as JSON path like $.key instead of Go syntax
+	jsonPath bool
+}
+
+// compare walks expected and actual and returns all differences found by path.
+// At most max differences are collected, the number of additional differences is
+// returned as second value.
+func compare(root string, expected, actual interface{}, max int) ([]difference, int) {
+	c := &comparer{max: max, visited: map[[2]uintptr]bool{}}
+	c.walk(root, reflect.ValueOf(expected), reflect.ValueOf(actual))
+
+	return c.diffs, c.more
+}
+
+// compareJSON works like compare for generic JSON values and reports the differences by JSON path.
+func compareJSON(expected, actual interface{}, max int) ([]difference, int) {
+	c := &comparer{max: max, visited: map[[2]uintptr]bool{}, jsonPath: true}
+	c.walk("$", reflect.ValueOf(expected), reflect.ValueOf(actual))
+
+	return c.diffs, c.more
+}
+
+func (c *comparer) add(path, f string, i ...interface{}) {
+	if len(c.diffs) >= c.max {
+		c.more++
+		return
+	}
+
+	c.diffs = append(c.diffs, difference{path: path, msg: fmt.Sprintf(f, i...)})
+}
+
+func (c *comparer) walk(path string, x, v reflect.Value) {
+	if !x.IsValid() || !v.IsValid() {
+		if x.IsValid() != v.IsValid() {
+			c.add(path, "expected %v but it is %v", formatValue(x), formatValue(v))
+		}
+
+		return
+	}
+
+	if x.Type() != v.Type() {
+		c.add(path, "expected type %v but it is %v", x.Type(), v.Type())
+		return
+	}
+
+	switch x.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if x.IsNil() || v.IsNil() {
+			if x.IsNil() != v.IsNil() {
+				c.add(path, "expected %v but it is %v", formatValue(x), formatValue(v))
+			}
+
+			return
+		}
+
+		if x.Kind() == reflect.Ptr {
+			key := [2]uintptr{x.Pointer(), v.Pointer()}
+			if key[0] == key[1] || c.visited[key] {
+				return
+			}
+
+			c.visited[key] = true
+		}
+
+		c.walk(path, x.Elem(), v.Elem())
+
+	case reflect.Struct:
+		if x.Type() == timeType {
+			c.leaf(path, x, v)
+			return
+		}
+
+		for i := 0; i < x.NumField(); i++ {
+			c.walk(path+"."+x.Type().Field(i).Name, x.Field(i), v.Field(i))
+		}
+
+	case reflect.Map:
+		if x.IsNil() != v.IsNil() {
+			c.add(path, "expected %v but it is %v", formatValue(x), formatValue(v))
+			return
+		}
+
+		for _, k := range sortedKeys(x, v) {
+			kp := c.keyPath(path, k)
+			xe := x.MapIndex(k)
+			ve := v.MapIndex(k)
+
+			switch {
+			case !ve.IsValid():
+				c.add(kp, "missing")
+			case !xe.IsValid():
+				c.add(kp, "unexpected %v", formatValue(ve))
+			default:
+				c.walk(kp, xe, ve)
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		if x.Kind() == reflect.Slice && x.IsNil() != v.IsNil() {
+			c.add(path, "expected %v but it is %v", formatValue(x), formatValue(v))
+			return
+		}
+
+		l := x.Len()
+		if v.Len() > l {
+			l = v.Len()
+		}
+
+		for i := 0; i < l; i++ {
+			ip := path + "[" + strconv.Itoa(i) + "]"
+
+			switch {
+			case i >= v.Len():
+				c.add(ip, "missing")
+			case i >= x.Len():
+				c.add(ip, "unexpected %v", formatValue(v.Index(i)))
+			default:
+				c.walk(ip, x.Index(i), v.Index(i))
+			}
+		}
+
+	default:
+		c.leaf(path, x, v)
+	}
+}
+
+// leaf compares two values which are not further walked.
+func (c *comparer) leaf(path string, x, v reflect.Value) {
+	if x.CanInterface() && v.CanInterface() {
+		if !reflect.DeepEqual(x.Interface(), v.Interface()) {
+			c.add(path, "expected %v but it is %v", formatValue(x), formatValue(v))
+		}
+
+		return
+	}
+
+	// unexported fields can not be converted back to an interface so
+	// we compare them by their kind specific value
+	equal := true
+
+	switch x.Kind() {
+	case reflect.Bool:
+		equal = x.Bool() == v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		equal = x.Int() == v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		equal = x.Uint() == v.Uint()
+	case reflect.Float32, reflect.Float64:
+		equal = x.Float() == v.Float()
+	case reflect.Complex64, reflect.Complex128:
+		equal = x.Complex() == v.Complex()
+	case reflect.String:
+		equal = x.String() == v.String()
+	case reflect.Chan, reflect.UnsafePointer:
+		equal = x.Pointer() == v.Pointer()
+	case reflect.Func:
+		equal = x.IsNil() && v.IsNil()
+	case reflect.Struct:
+		// time.Time in an unexported field
+		equal = fmt.Sprint(x) == fmt.Sprint(v)
+	}
+
+	if !equal {
+		c.add(path, "expected %v but it is %v", formatValue(x), formatValue(v))
+	}
+}
+
+// keyPath appends the map key to path.
+func (c *comparer) keyPath(path string, k reflect.Value) string {
+	if c.jsonPath && k.Kind() == reflect.String && jsonIdentifier.MatchString(k.String()) {
+		return path + "." + k.String()
+	}
+
+	return path + "[" + formatKey(k) + "]"
+}
+
+// formatValue formats a single value compact so it can be used on one line.
+func formatValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		if v.IsNil() {
+			return "nil"
+		}
+	}
+
+	if !v.CanInterface() {
+		if v.Kind() == reflect.String {
+			return strconv.Quote(v.String())
+		}
+
+		return fmt.Sprint(v)
+	}
+
+	i := v.Interface()
+
+	switch t := i.(type) {
+	case string:
+		if strings.Contains(t, "\n") {
+			return strconv.Quote(t)
+		}
+
+		return "'" + t + "'"
+	case time.Time:
+		return t.Format(time.RFC3339Nano)
+	case json.Number:
+		return t.String()
+	}
+
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.Struct, reflect.Ptr:
+		return fmt.Sprintf("%+v", i)
+	}
+
+	f, _ := formatOne(i)
+
+	return strings.ReplaceAll(f, "\n", "\\n")
+}
+
+// formatKey formats a map key as it is used in a path.
+func formatKey(k reflect.Value) string {
+	if k.Kind() == reflect.String {
+		return strconv.Quote(k.String())
+	}
+
+	return fmt.Sprint(k)
+}
+
+// sortedKeys returns the keys of both maps in a stable order.
+func sortedKeys(x, v reflect.Value) []reflect.Value {
+	seen := map[string]bool{}
+	keys := []reflect.Value{}
+
+	for _, m := range []reflect.Value{x, v} {
+		for _, k := range m.MapKeys() {
+			f := fmt.Sprintf("%#v", k)
+			if !seen[f] {
+				seen[f] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return formatKey(keys[i]) < formatKey(keys[j])
+	})
+
+	return keys
+}
+
+// isStructural returns true for values which are compared by path.
+func isStructural(i interface{}) bool {
+	if i == nil {
+		return false
+	}
+
+	t := reflect.TypeOf(i)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return false
+	}
+
+	switch t.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.Struct:
+		return true
+	}
+
+	return false
+}
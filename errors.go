@@ -0,0 +1,162 @@
+package expect
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// ToWrap asserts that the error is or wraps the target error, it's checked with errors.Is.
+func (e Val) ToWrap(target error) Val {
+	e.t.Helper()
+	e.check(e.toWrap(target))
+
+	return e
+}
+
+func (e Val) toWrap(target error) result {
+	e.t.Helper()
+
+	err, ok := e.asError("ToWrap")
+	if !ok {
+		return result{}
+	}
+
+	return result{
+		pass: errors.Is(err, target),
+		message: func(negated bool) string {
+			does := "it does not"
+			if negated {
+				does = "it does"
+			}
+
+			return fmt.Sprintf("expected %v%v to wrap %v but %v%v", e.name, not(negated), formatError(target), does, errorChain(err))
+		},
+	}
+}
+
+// ToBeErrorAs asserts that the error or an error it wraps can be assigned to target, it's checked
+// with errors.As. Target must be a non-nil pointer to an error type or interface. The returned
+// value is the extracted error.
+func (e Val) ToBeErrorAs(target interface{}) Val {
+	e.t.Helper()
+
+	r, as := e.toBeErrorAs(target)
+	e.check(r)
+
+	return as
+}
+
+func (e Val) toBeErrorAs(target interface{}) (result, Val) {
+	e.t.Helper()
+
+	tv := reflect.ValueOf(target)
+	if target == nil || tv.Kind() != reflect.Ptr || tv.IsNil() ||
+		(tv.Elem().Kind() != reflect.Interface && !tv.Type().Elem().Implements(errorType)) {
+		e.t.Fatalf("ToBeErrorAs target must be a non-nil pointer to an error type or interface but it is %T", target)
+		return result{}, e
+	}
+
+	err, ok := e.asError("ToBeErrorAs")
+	if !ok {
+		return result{}, e
+	}
+
+	typ := tv.Type().Elem()
+	pass := err != nil && errors.As(err, target)
+
+	as := Val{
+		ex:   e.ex,
+		name: e.name + " as " + typ.String(),
+		t:    e.t,
+	}
+
+	// without a match the target holds a typed nil, which must not be chained
+	if pass {
+		as.value = tv.Elem().Interface()
+	}
+
+	return result{
+		pass: pass,
+		message: func(negated bool) string {
+			if negated {
+				return fmt.Sprintf("expected %v NOT to be %v but it is%v", e.name, typ, errorChain(err))
+			}
+
+			return fmt.Sprintf("expected %v to be %v but it is not%v", e.name, typ, errorChain(err))
+		},
+	}, as
+}
+
+// ToBeErrorOfType asserts that the error or an error it wraps is of type T, it's checked with
+// errors.As. The returned value is the extracted error.
+func ToBeErrorOfType[T error](v Val) Val {
+	v.t.Helper()
+
+	var target T
+
+	return v.ToBeErrorAs(&target)
+}
+
+// asError returns the value as error, nil is a valid error value.
+func (e Val) asError(matcher string) (error, bool) {
+	e.t.Helper()
+
+	if e.value == nil {
+		return nil, true
+	}
+
+	err, is := e.value.(error)
+	if !is {
+		e.t.Fatalf("%v must only be called on an error value but it is %T", matcher, e.value)
+		return nil, false
+	}
+
+	return err, true
+}
+
+// errorChain formats the error and all errors it wraps, one per line. Errors joined with
+// errors.Join or other multi-errors are indented below the error wrapping them.
+func errorChain(err error) string {
+	if err == nil {
+		return ", the error is nil"
+	}
+
+	lines := []string{", the chain is:"}
+
+	var walk func(err error, depth int)
+	walk = func(err error, depth int) {
+		for err != nil {
+			lines = append(lines, strings.Repeat("    ", depth+1)+formatError(err))
+
+			switch u := err.(type) {
+			case interface{ Unwrap() []error }:
+				for _, c := range u.Unwrap() {
+					walk(c, depth+1)
+				}
+
+				return
+			case interface{ Unwrap() error }:
+				err = u.Unwrap()
+			default:
+				return
+			}
+		}
+	}
+
+	walk(err, 0)
+
+	return strings.Join(lines, "\n")
+}
+
+// formatError formats the error with its type.
+func formatError(err error) string {
+	if err == nil {
+		return "nil"
+	}
+
+	return fmt.Sprintf("%T '%v'", err, strings.ReplaceAll(err.Error(), "\n", "\\n"))
+}
@@ -2,6 +2,7 @@ package expect
 
 import (
 	"encoding/json"
+	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
@@ -18,10 +19,28 @@ var (
 
 type Expect struct {
 	Output output
+	// MaxDifferences limits the number of differences reported when structs, maps
+	// slices or arrays do not match. Defaults to 10 when not set.
+	MaxDifferences int
+	// UpdateSnapshots overwrites snapshots which do not match the current output instead of failing.
+	// The update mode can also be enabled with the environment variable EXPECT_UPDATE_SNAPSHOTS=1
+	// or the test flag -expect.update.
+	UpdateSnapshots bool
+	// SnapshotRoot is the folder for snapshots named after the test by ToMatchSnapshot
+	// and ToMatchSnapshotImage. Defaults to testdata/snapshots when not set.
+	SnapshotRoot string
+	// SnapshotFormat is the format of snapshots when neither the WithFormat option is given nor
+	// the format can be inferred from the extension of the snapshot path. Defaults to YAMLFormat.
+	SnapshotFormat SnapshotFormat
+	// SnapshotReport is the path of an HTML report of all failed snapshots of the test run. The
+	// report can also be enabled with the environment variable EXPECT_SNAPSHOT_REPORT=<path>.
+	// The package name is added to the file name, report.html becomes report-<package>.html.
+	SnapshotReport string
 }
 
 var Default = &Expect{
-	Output: PlainOutput,
+	Output:         PlainOutput,
+	MaxDifferences: defaultMaxDifferences,
 }
 
 // Value wraps a value and provides expectations for this value.
@@ -36,6 +55,14 @@ func Error(t Test, val interface{}) Val {
 	return Default.Error(t, val)
 }
 
+func (e *Expect) maxDifferences() int {
+	if e.MaxDifferences <= 0 {
+		return defaultMaxDifferences
+	}
+
+	return e.MaxDifferences
+}
+
 // Value wraps a value and provides expectations for this value.
 func (e *Expect) Value(t Test, name string, val interface{}) Val {
 	return Val{
@@ -49,59 +76,122 @@ func (e *Expect) Value(t Test, name string, val interface{}) Val {
 // Error wraps an error and provides expectations for this value.
 // This is a shortcut for Value(t, "error", val).
 func (e *Expect) Error(t Test, val interface{}) Val {
-	return Value(t, "error", val)
+	return e.Value(t, "error", val)
 }
 
 // Val to call expectations on.
 type Val struct {
-	ex    *Expect
-	name  string
-	t     Test
-	value interface{}
+	ex     *Expect
+	name   string
+	t      Test
+	value  interface{}
+	negate bool
+}
+
+// Not returns a negated value, all expectations called on it must not be met. Values derived
+// from it with First, Last, Message, Group or ToBeErrorAs are not negated.
+// Snapshot expectations can not be negated.
+func (e Val) Not() Val {
+	e.negate = !e.negate
+	return e
 }
 
 // ToBe asserts that the value is deeply equals to expected value.
 func (e Val) ToBe(expected interface{}) Val {
 	e.t.Helper()
+	e.check(e.toBe(expected))
+
+	return e
+}
 
+func (e Val) toBe(expected interface{}) result {
 	if !sameType(e.value, expected) {
-		e.t.Errorf("expected %v to be of type %v but it is of type %v", e.name, typeName(expected), typeName(e.value))
-		return e
+		return result{
+			pass: false,
+			message: func(negated bool) string {
+				if negated {
+					return e.notToBeMessage(expected)
+				}
+
+				return fmt.Sprintf("expected %v to be of type %v but it is of type %v", e.name, typeName(expected), typeName(e.value))
+			},
+		}
 	}
 
 	// if both are some kind of nil we are fine
 	if isNil(e.value) && isNil(expected) {
-		return e
+		return result{
+			pass: true,
+			message: func(bool) string {
+				return e.notToBeMessage(expected)
+			},
+		}
 	}
 
-	if !reflect.DeepEqual(e.value, expected) {
-		x, v, del := formatBoth(expected, e.value)
-		if e.ex.Output == ColoredDiffOutput && (len(x) > 20 || len(v) > 20) {
-			dmp := diffmatchpatch.New()
-			diffs := dmp.DiffMainRunes([]rune(v), []rune(x), false)
-			diffs = dmp.DiffCleanupSemantic(diffs)
-			txt := dmp.DiffPrettyText(diffs)
-			txt = strings.ReplaceAll(txt, " ", "․")
-			txt = strings.ReplaceAll(txt, "\t", "↦")
-			txt = strings.ReplaceAll(txt, "\n", "↵\n")
-			txt = strings.ReplaceAll(txt, "\r", "↵\n")
-			e.t.Error(txt)
-		} else {
-			pres := presentations[del]
-			e.t.Errorf("expected %v to be%v%v%vbut it is%v%v", e.name, pres, indent(x, del), pres, pres, indent(v, del))
+	return e.deepEqual(expected)
+}
+
+func (e Val) deepEqual(expected interface{}) result {
+	return result{
+		pass: reflect.DeepEqual(e.value, expected),
+		message: func(negated bool) string {
+			if negated {
+				return e.notToBeMessage(expected)
+			}
+
+			return e.toBeMessage(expected)
+		},
+	}
+}
+
+func (e Val) toBeMessage(expected interface{}) string {
+	if e.ex.Output != ColoredDiffOutput && isStructural(expected) {
+		diffs, more := compare(e.name, expected, e.value, e.ex.maxDifferences())
+		if len(diffs) > 0 {
+			return formatDifferences(e.name, diffs, more)
 		}
 	}
 
-	return e
+	x, v, del := formatBoth(expected, e.value)
+	if e.ex.Output == ColoredDiffOutput && (len(x) > 20 || len(v) > 20) {
+		dmp := diffmatchpatch.New()
+		diffs := dmp.DiffMainRunes([]rune(v), []rune(x), false)
+		diffs = dmp.DiffCleanupSemantic(diffs)
+		txt := dmp.DiffPrettyText(diffs)
+		txt = strings.ReplaceAll(txt, " ", "․")
+		txt = strings.ReplaceAll(txt, "\t", "↦")
+		txt = strings.ReplaceAll(txt, "\n", "↵\n")
+		txt = strings.ReplaceAll(txt, "\r", "↵\n")
+
+		return txt
+	}
+
+	pres := presentations[del]
+
+	return fmt.Sprintf("expected %v to be%v%v%vbut it is%v%v", e.name, pres, indent(x, del), pres, pres, indent(v, del))
+}
+
+func (e Val) notToBeMessage(unExpected interface{}) string {
+	x, p := formatOne(unExpected)
+	nl := presentations[p]
+
+	return fmt.Sprintf("expected %v to NOT be%v%v%vbut it is", e.name, nl, x, nl)
 }
 
 // ToCount asserts that the list/map/chan/string has c elements. Strings use the number of unicode chars.
 func (e Val) ToCount(c int) Val {
 	e.t.Helper()
+	e.check(e.toCount(c))
+
+	return e
+}
+
+func (e Val) toCount(c int) result {
+	e.t.Helper()
 
 	if !hasLen(e.value) {
 		e.t.Fatalf("%v is not a datatype with a length (array, slice, map, chan, string)", e.name)
-		return e
+		return result{}
 	}
 
 	l := reflect.ValueOf(e.value).Len()
@@ -111,57 +201,84 @@ func (e Val) ToCount(c int) Val {
 		l = len([]rune(str))
 	}
 
-	if l != c {
-		e.t.Errorf("expected %v to have %v elements but it has %v elements", e.name, c, l)
-	}
+	return result{
+		pass: l == c,
+		message: func(negated bool) string {
+			if negated {
+				return fmt.Sprintf("expected %v NOT to have %v elements but it does", e.name, c)
+			}
 
-	return e
+			return fmt.Sprintf("expected %v to have %v elements but it has %v elements", e.name, c, l)
+		},
+	}
 }
 
 // ToContain checks if the expected value is in the expected slice or if the string contains a substring or not.
 // Does a deep equal for slices.
 func (e Val) ToContain(expected interface{}) Val {
+	e.t.Helper()
+	e.check(e.toContain(expected))
+
+	return e
+}
+
+func (e Val) toContain(expected interface{}) result {
+	e.t.Helper()
+
+	notContained := func(negated bool) string {
+		x, _ := formatOne(expected)
+		return fmt.Sprintf("expected %v NOT to contain %v but it does", e.name, x)
+	}
+
 	v := reflect.ValueOf(e.value)
 	if v.Kind() == reflect.String {
-		if !strings.Contains(v.String(), expected.(string)) {
-			e.t.Errorf("expected %v to be in %v %v but it is not", expected, e.name, e.value)
+		return result{
+			pass: strings.Contains(v.String(), expected.(string)),
+			message: func(negated bool) string {
+				if negated {
+					return notContained(negated)
+				}
+
+				return fmt.Sprintf("expected %v to be in %v %v but it is not", expected, e.name, e.value)
+			},
 		}
-
-		return e
 	}
 
 	if v.Kind() != reflect.Slice {
 		e.t.Fatalf("expected %v to be string or slice, but it is a %T", e.value, e.value)
+		return result{}
 	}
 
 	for i := 0; i < v.Len(); i++ {
 		element := v.Index(i).Interface()
 		if reflect.DeepEqual(element, expected) {
-			return e
+			return result{pass: true, message: notContained}
 		}
 	}
 
-	exp, erre := json.Marshal(expected)
-	val, errv := json.Marshal(e.value)
+	return result{
+		pass: false,
+		message: func(negated bool) string {
+			if negated {
+				return notContained(negated)
+			}
 
-	if erre != nil || errv != nil {
-		e.t.Errorf("expected %v to be in %v %v but it is not", expected, e.name, e.value)
-	} else {
-		e.t.Errorf("expected %v to be in %v %v but it is not", string(exp), e.name, string(val))
-	}
+			exp, erre := json.Marshal(expected)
+			val, errv := json.Marshal(e.value)
 
-	return e
+			if erre != nil || errv != nil {
+				return fmt.Sprintf("expected %v to be in %v %v but it is not", expected, e.name, e.value)
+			}
+
+			return fmt.Sprintf("expected %v to be in %v %v but it is not", string(exp), e.name, string(val))
+		},
+	}
 }
 
 // NotToBe asserts that the value is not deeply equals to expected value.
 func (e Val) NotToBe(unExpected interface{}) Val {
 	e.t.Helper()
-
-	if reflect.DeepEqual(e.value, unExpected) {
-		x, p := formatOne(unExpected)
-		nl := presentations[p]
-		e.t.Errorf("expected %v to NOT be%v%v%vbut it is", e.name, nl, x, nl)
-	}
+	e.Not().check(e.deepEqual(unExpected))
 
 	return e
 }
@@ -170,6 +287,13 @@ func (e Val) NotToBe(unExpected interface{}) Val {
 // Only works for numbers.
 func (e Val) ToBeAbout(expected, delta float64) Val {
 	e.t.Helper()
+	e.check(e.toBeAbout(expected, delta))
+
+	return e
+}
+
+func (e Val) toBeAbout(expected, delta float64) result {
+	e.t.Helper()
 
 	val := 0.0
 	switch t := e.value.(type) {
@@ -199,58 +323,93 @@ func (e Val) ToBeAbout(expected, delta float64) Val {
 		val = float64(t)
 	default:
 		e.t.Fatalf("ToBeAbout() can only work on number values but it's called on type %T", e.value)
+		return result{}
 	}
 
-	if val < expected-delta || val > expected+delta {
-		e.t.Errorf("expected %v to be %v±%v but it is %v", e.name, expected, delta, e.value)
-	}
+	return result{
+		pass: val >= expected-delta && val <= expected+delta,
+		message: func(negated bool) string {
+			if negated {
+				return fmt.Sprintf("expected %v NOT to be %v±%v but it is %v", e.name, expected, delta, e.value)
+			}
 
-	return e
+			return fmt.Sprintf("expected %v to be %v±%v but it is %v", e.name, expected, delta, e.value)
+		},
+	}
 }
 
 // ToHavePrefix asserts that the string value starts with the provided prefix.
 func (e Val) ToHavePrefix(prefix string) Val {
 	e.t.Helper()
+	e.check(e.toHavePrefix(prefix))
+
+	return e
+}
+
+func (e Val) toHavePrefix(prefix string) result {
+	e.t.Helper()
 
 	actual, is := e.value.(string)
 	if !is {
 		e.t.Fatalf("ToHavePrefix must only be called on a string value")
+		return result{}
 	}
 
-	if !strings.HasPrefix(actual, prefix) {
-		e.t.Errorf("expected %v to have prefix '%v' but it is '%v'", e.name, prefix, actual)
+	return result{
+		pass: strings.HasPrefix(actual, prefix),
+		message: func(negated bool) string {
+			return fmt.Sprintf("expected %v%v to have prefix '%v' but it is '%v'", e.name, not(negated), prefix, actual)
+		},
 	}
-
-	return e
 }
 
 // ToHaveSuffix asserts that the string value ends with the provided sufix.
 func (e Val) ToHaveSuffix(suffix string) Val {
 	e.t.Helper()
+	e.check(e.toHaveSuffix(suffix))
+
+	return e
+}
+
+func (e Val) toHaveSuffix(suffix string) result {
+	e.t.Helper()
 
 	actual, is := e.value.(string)
 	if !is {
 		e.t.Fatalf("ToHaveSuffix must only be called on a string value")
+		return result{}
 	}
 
-	if !strings.HasSuffix(actual, suffix) {
-		e.t.Errorf("expected %v to have suffix '%v' but it is '%v'", e.name, suffix, actual)
+	return result{
+		pass: strings.HasSuffix(actual, suffix),
+		message: func(negated bool) string {
+			return fmt.Sprintf("expected %v%v to have suffix '%v' but it is '%v'", e.name, not(negated), suffix, actual)
+		},
 	}
-
-	return e
 }
 
+// ToBeType asserts that the value is of the same type as the provided value.
 func (e Val) ToBeType(t any) Val {
 	e.t.Helper()
+	e.check(e.toBeType(t))
+
+	return e
+}
 
+func (e Val) toBeType(t any) result {
 	t1 := reflect.TypeOf(e.value)
 	t2 := reflect.TypeOf(t)
 
-	if t1 != t2 {
-		e.t.Errorf("expected %v to be of type '%v' but it is of type '%v'", e.name, t2, t1)
-	}
+	return result{
+		pass: t1 == t2,
+		message: func(negated bool) string {
+			if negated {
+				return fmt.Sprintf("expected %v NOT to be of type '%v' but it is", e.name, t2)
+			}
 
-	return e
+			return fmt.Sprintf("expected %v to be of type '%v' but it is of type '%v'", e.name, t2, t1)
+		},
+	}
 }
 
 // Message creates a new value from the given errors message. If the error is nil the message
@@ -363,6 +522,10 @@ func isIndexable(v interface{}) bool {
 }
 
 func hasLen(v interface{}) bool {
+	if v == nil {
+		return false
+	}
+
 	switch reflect.TypeOf(v).Kind() {
 	case reflect.Array:
 		return true
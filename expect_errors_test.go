@@ -0,0 +1,106 @@
+package expect_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/akabio/expect"
+	"github.com/akabio/expect/internal/test"
+)
+
+var errNotFound = errors.New("not found")
+
+// multiError wraps several errors like errors.Join.
+type multiError []error
+
+func (m multiError) Error() string {
+	return fmt.Sprintf("%v errors", len(m))
+}
+
+func (m multiError) Unwrap() []error {
+	return m
+}
+
+func TestToWrap(t *testing.T) {
+	err := fmt.Errorf("load user: %w", errNotFound)
+
+	expect.Error(t, err).ToWrap(errNotFound)
+	expect.Error(t, errNotFound).ToWrap(errNotFound)
+	expect.Error(t, err).Not().ToWrap(fs.ErrNotExist)
+	expect.Error(t, nil).Not().ToWrap(errNotFound)
+	expect.Error(t, multiError{fs.ErrClosed, err}).ToWrap(errNotFound)
+}
+
+func TestToWrapFail(t *testing.T) {
+	l := test.New(t, func(t expect.Test) {
+		expect.Error(t, fmt.Errorf("load user: %w", errNotFound)).ToWrap(fs.ErrNotExist)
+		expect.Error(t, fmt.Errorf("load user: %w", errNotFound)).Not().ToWrap(errNotFound)
+		expect.Error(t, multiError{fs.ErrClosed, fmt.Errorf("load user: %w", errNotFound)}).ToWrap(fs.ErrNotExist)
+		expect.Error(t, nil).ToWrap(errNotFound)
+	})
+	l.ExpectMessages().ToCount(4)
+	l.ExpectMessage(0).ToBe(`expected error to wrap *errors.errorString 'file does not exist' but it does not, the chain is:
+    *fmt.wrapError 'load user: not found'
+    *errors.errorString 'not found'`)
+	l.ExpectMessage(1).ToBe(`expected error NOT to wrap *errors.errorString 'not found' but it does, the chain is:
+    *fmt.wrapError 'load user: not found'
+    *errors.errorString 'not found'`)
+	l.ExpectMessage(2).ToBe(`expected error to wrap *errors.errorString 'file does not exist' but it does not, the chain is:
+    expect_test.multiError '2 errors'
+        *errors.errorString 'file already closed'
+        *fmt.wrapError 'load user: not found'
+        *errors.errorString 'not found'`)
+	l.ExpectMessage(3).ToBe("expected error to wrap *errors.errorString 'not found' but it does not, the error is nil")
+
+	l = test.New(t, func(t expect.Test) {
+		expect.Value(t, "name", "bob").ToWrap(errNotFound)
+	})
+	l.ExpectMessage(0).ToBe("ToWrap must only be called on an error value but it is string")
+}
+
+func TestToBeErrorAs(t *testing.T) {
+	_, err := os.Open("testdata/does-not-exist")
+	err = fmt.Errorf("load config: %w", err)
+
+	var pathErr *fs.PathError
+	expect.Error(t, err).ToBeErrorAs(&pathErr).Message().ToBe("open testdata/does-not-exist: no such file or directory")
+	expect.Value(t, "op", pathErr.Op).ToBe("open")
+
+	expect.ToBeErrorOfType[*fs.PathError](expect.Error(t, err)).Message().ToHavePrefix("open")
+	expect.ToBeErrorOfType[multiError](expect.Error(t, err).Not())
+}
+
+func TestToBeErrorAsFail(t *testing.T) {
+	l := test.New(t, func(t expect.Test) {
+		var pathErr *fs.PathError
+		expect.Error(t, fmt.Errorf("load user: %w", errNotFound)).ToBeErrorAs(&pathErr)
+		expect.ToBeErrorOfType[*fs.PathError](expect.Error(t, &fs.PathError{Op: "open", Path: "x", Err: fs.ErrNotExist}).Not())
+		expect.ToBeErrorOfType[*fs.PathError](expect.Error(t, nil))
+	})
+	l.ExpectMessages().ToCount(3)
+	l.ExpectMessage(0).ToBe(`expected error to be *fs.PathError but it is not, the chain is:
+    *fmt.wrapError 'load user: not found'
+    *errors.errorString 'not found'`)
+	l.ExpectMessage(1).ToBe(`expected error NOT to be *fs.PathError but it is, the chain is:
+    *fs.PathError 'open x: file does not exist'
+    *errors.errorString 'file does not exist'`)
+	l.ExpectMessage(2).ToBe("expected error to be *fs.PathError but it is not, the error is nil")
+
+	l = test.New(t, func(t expect.Test) {
+		var pathErr fs.PathError
+		expect.Error(t, errNotFound).ToBeErrorAs(&pathErr)
+	})
+	l.ExpectMessage(0).ToBe("ToBeErrorAs target must be a non-nil pointer to an error type or interface but it is *fs.PathError")
+}
+
+func TestToBeErrorAsFailChained(t *testing.T) {
+	l := test.New(t, func(t expect.Test) {
+		var pathErr *fs.PathError
+		expect.Error(t, errNotFound).ToBeErrorAs(&pathErr).Message().ToHavePrefix("open")
+	})
+	l.ExpectMessages().ToCount(2)
+	l.ExpectMessage(1).ToBe("expected error as *fs.PathError message to have prefix 'open' but it is ''")
+}
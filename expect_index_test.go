@@ -42,3 +42,8 @@ func TestFailLastInMap(t *testing.T) {
 	})
 	l.ExpectMessage(0).ToBe("map is not an indexable datatype")
 }
+
+func TestFirstOfNegatedIsNotNegated(t *testing.T) {
+	expect.Value(t, "int slice", []int{1, 2, 3}).Not().ToBeEmpty().First().ToBe(1)
+	expect.Value(t, "int slice", []int{1, 2, 3}).Not().ToBeEmpty().Last().ToBe(3)
+}
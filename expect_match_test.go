@@ -0,0 +1,63 @@
+package expect_test
+
+import (
+	"testing"
+
+	"github.com/akabio/expect"
+	"github.com/akabio/expect/internal/test"
+)
+
+const logLine = "2023-08-01T12:00:00Z INFO user 42 logged in"
+
+func TestToMatch(t *testing.T) {
+	expect.Value(t, "log line", logLine).ToMatch(`^\S+ INFO user \d+ logged in$`)
+	expect.Value(t, "log line", logLine).ToMatch(`user \d+`)
+	expect.Value(t, "log line", logLine).Not().ToMatch(`ERROR`)
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "log line", logLine).ToMatch(`^ERROR`)
+		expect.Value(t, "log line", logLine).Not().ToMatch(`INFO`)
+	})
+	l.ExpectMessages().ToCount(2)
+	l.ExpectMessage(0).ToBe("expected log line to match '^ERROR' but it is '" + logLine + "'")
+	l.ExpectMessage(1).ToBe("expected log line NOT to match 'INFO' but it is '" + logLine + "'")
+
+	l = test.New(t, func(t expect.Test) {
+		expect.Value(t, "log line", logLine).ToMatch(`(`)
+	})
+	l.ExpectMessage(0).ToBe("ToMatch pattern '(' is invalid, error parsing regexp: missing closing ): `(`")
+}
+
+func TestToMatchGlob(t *testing.T) {
+	expect.Value(t, "file", "report-2023.pdf").ToMatchGlob("report-*.pdf")
+	expect.Value(t, "file", "out/report.pdf").Not().ToMatchGlob("*.pdf")
+	expect.Value(t, "file", "img7.png").ToMatchGlob("img[0-9].png")
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "file", "report.txt").ToMatchGlob("*.pdf")
+		expect.Value(t, "file", "report.txt").ToMatchGlob("[")
+	})
+	l.ExpectMessages().ToCount(2)
+	l.ExpectMessage(0).ToBe("expected file to match glob '*.pdf' but it is 'report.txt'")
+	l.ExpectMessage(1).ToBe("ToMatchGlob pattern '[' is invalid, syntax error in pattern")
+}
+
+func TestMatchingGroup(t *testing.T) {
+	expect.Value(t, "log line", logLine).Matching(`user (\d+)`).Group(1).ToBe("42")
+	expect.Value(t, "log line", logLine).Matching(`user (\d+)`).Group(0).ToBe("user 42")
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "log line", logLine).Matching(`(\w+) logged in`).Group(1).ToBe("bob")
+	})
+	l.ExpectMessage(0).ToBe("expected group 1 of log line to be 'bob' but it is '42'")
+
+	l = test.New(t, func(t expect.Test) {
+		expect.Value(t, "log line", logLine).Matching(`admin (\d+)`).Group(1)
+	})
+	l.ExpectMessage(0).ToBe("expected log line to match 'admin (\\d+)' but it is '" + logLine + "'")
+
+	l = test.New(t, func(t expect.Test) {
+		expect.Value(t, "log line", logLine).Matching(`user (\d+)`).Group(2)
+	})
+	l.ExpectMessage(0).ToBe("'user (\\d+)' has 1 groups, group 2 is out of bounds")
+}
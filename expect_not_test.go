@@ -0,0 +1,56 @@
+package expect_test
+
+import (
+	"testing"
+
+	"github.com/akabio/expect"
+	"github.com/akabio/expect/internal/test"
+)
+
+func TestNot(t *testing.T) {
+	expect.Value(t, "number", 7).Not().ToBe(8)
+	expect.Value(t, "number", 7).Not().ToBe("7")
+	expect.Value(t, "list", []string{"a", "b"}).Not().ToContain("x")
+	expect.Value(t, "statement", "we are all crazy").Not().ToContain("nuts")
+	expect.Value(t, "statement", "we are all crazy").Not().ToHavePrefix("i am")
+	expect.Value(t, "statement", "we are all crazy").Not().ToHaveSuffix("all nuts")
+	expect.Value(t, "list", []string{"a", "b"}).Not().ToCount(3)
+	expect.Value(t, "liters", 1.92).Not().ToBeAbout(3, 0.1)
+	expect.Value(t, "foo", 7).Not().ToBeType("")
+}
+
+func TestNotNotToBe(t *testing.T) {
+	expect.Value(t, "number", 7).Not().NotToBe(7)
+	expect.Value(t, "number", 7).Not().Not().ToBe(7)
+}
+
+func TestFailNot(t *testing.T) {
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "number", 7).Not().ToBe(7)
+		expect.Value(t, "list", []string{"a", "x"}).Not().ToContain("x")
+		expect.Value(t, "statement", "we are all crazy").Not().ToContain("crazy")
+		expect.Value(t, "statement", "we are all crazy").Not().ToHavePrefix("we are")
+		expect.Value(t, "statement", "we are all crazy").Not().ToHaveSuffix("crazy")
+		expect.Value(t, "list", []string{"a", "b"}).Not().ToCount(2)
+		expect.Value(t, "liters", 1.92).Not().ToBeAbout(2, 0.1)
+		expect.Value(t, "foo", 7).Not().ToBeType(0)
+		expect.Value(t, "number", 7).Not().NotToBe(8)
+	})
+	l.ExpectMessages().ToCount(9)
+	l.ExpectMessage(0).ToBe("expected number to NOT be 7 but it is")
+	l.ExpectMessage(1).ToBe("expected list NOT to contain 'x' but it does")
+	l.ExpectMessage(2).ToBe("expected statement NOT to contain 'crazy' but it does")
+	l.ExpectMessage(3).ToBe("expected statement NOT to have prefix 'we are' but it is 'we are all crazy'")
+	l.ExpectMessage(4).ToBe("expected statement NOT to have suffix 'crazy' but it is 'we are all crazy'")
+	l.ExpectMessage(5).ToBe("expected list NOT to have 2 elements but it does")
+	l.ExpectMessage(6).ToBe("expected liters NOT to be 2±0.1 but it is 1.92")
+	l.ExpectMessage(7).ToBe("expected foo NOT to be of type 'int' but it is")
+	l.ExpectMessage(8).ToBe("expected number to be 8 but it is 7")
+}
+
+func TestErrorNotSnapshot(t *testing.T) {
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "content", "x").Not().ToBeSnapshot("testdata/volatile/not.txt")
+	})
+	l.ExpectMessage(0).ToBe("ToBeSnapshot can not be negated")
+}
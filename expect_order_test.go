@@ -0,0 +1,124 @@
+package expect_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/akabio/expect"
+	"github.com/akabio/expect/internal/test"
+)
+
+// version has a Compare method like semver implementations.
+type version struct{ major, minor int }
+
+func (v version) Compare(o version) int {
+	if v.major != o.major {
+		return v.major - o.major
+	}
+
+	return v.minor - o.minor
+}
+
+// day has a Before method like time.Time.
+type day struct{ n int }
+
+func (d day) Before(o day) bool {
+	return d.n < o.n
+}
+
+func TestOrdered(t *testing.T) {
+	expect.Value(t, "count", 3).ToBeGreaterThan(2).ToBeGreaterOrEqual(3).ToBeLessThan(4).ToBeLessOrEqual(3)
+	expect.Value(t, "count", int64(3)).ToBeGreaterThan(2)
+	expect.Value(t, "count", uint8(3)).ToBeGreaterThan(-1)
+	expect.Value(t, "count", -1).ToBeLessThan(uint64(1 << 63))
+	expect.Value(t, "ratio", 0.5).ToBeBetween(0, 1)
+	expect.Value(t, "ratio", float32(0.5)).ToBeLessThan(1)
+	expect.Value(t, "name", "bob").ToBeGreaterThan("alice")
+	expect.Value(t, "latency", 150*time.Millisecond).ToBeLessThan(200 * time.Millisecond)
+
+	now := time.Now()
+	expect.Value(t, "time", now).ToBeGreaterThan(now.Add(-time.Hour)).ToBeBetween(now, now)
+
+	expect.Value(t, "version", version{1, 2}).ToBeGreaterThan(version{1, 1}).ToBeLessThan(version{2, 0})
+	expect.Value(t, "day", day{3}).ToBeBetween(day{3}, day{5}).Not().ToBeLessThan(day{3})
+	expect.Value(t, "count", 3).Not().ToBeBetween(4, 5)
+}
+
+func TestOrderedFail(t *testing.T) {
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "count", 3).ToBeGreaterThan(3)
+		expect.Value(t, "count", 3).ToBeGreaterOrEqual(4)
+		expect.Value(t, "latency", 250*time.Millisecond).ToBeLessThan(200 * time.Millisecond)
+		expect.Value(t, "name", "bob").ToBeLessOrEqual("alice")
+		expect.Value(t, "ratio", 1.5).ToBeBetween(0, 1)
+		expect.Value(t, "ratio", 0.5).Not().ToBeBetween(0, 1)
+		expect.Value(t, "count", 3).Not().ToBeLessThan(4)
+	})
+	l.ExpectMessages().ToCount(7)
+	l.ExpectMessage(0).ToBe("expected count to be greater than 3 but it is 3")
+	l.ExpectMessage(1).ToBe("expected count to be greater than or equal to 4 but it is 3")
+	l.ExpectMessage(2).ToBe("expected latency to be less than 200ms but it is 250ms")
+	l.ExpectMessage(3).ToBe("expected name to be less than or equal to 'alice' but it is 'bob'")
+	l.ExpectMessage(4).ToBe("expected ratio to be between 0 and 1 but it is 1.5")
+	l.ExpectMessage(5).ToBe("expected ratio NOT to be between 0 and 1 but it is 0.5")
+	l.ExpectMessage(6).ToBe("expected count NOT to be less than 4 but it is 3")
+}
+
+func TestOrderedIncomparable(t *testing.T) {
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "count", 3).ToBeGreaterThan("2")
+	})
+	l.ExpectMessage(0).ToBe("ToBeGreaterThan() can not compare int with string")
+
+	l = test.New(t, func(t expect.Test) {
+		expect.Value(t, "list", []int{1}).ToBeBetween(0, 1)
+	})
+	l.ExpectMessage(0).ToBe("ToBeBetween() can not compare []int with int and int")
+
+	l = test.New(t, func(t expect.Test) {
+		expect.Value(t, "d", 200*time.Millisecond).ToBeLessThan(100)
+	})
+	l.ExpectMessage(0).ToBe("ToBeLessThan() can not compare time.Duration with int")
+
+	l = test.New(t, func(t expect.Test) {
+		expect.Value(t, "d", 1.5).ToBeGreaterThan(time.Second)
+	})
+	l.ExpectMessage(0).ToBe("ToBeGreaterThan() can not compare float64 with time.Duration")
+}
+
+func TestDurationAndTimeFormat(t *testing.T) {
+	at := time.Date(2023, 8, 1, 11, 50, 18, 0, time.UTC)
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "d", time.Second).ToBe(2 * time.Second)
+		expect.Value(t, "at", at).ToBeGreaterThan(at.Add(time.Hour))
+	})
+	l.ExpectMessages().ToCount(2)
+	l.ExpectMessage(0).ToBe("expected d to be 2s but it is 1s")
+	l.ExpectMessage(1).ToBe("expected at to be greater than 2023-08-01T12:50:18Z but it is 2023-08-01T11:50:18Z")
+}
+
+func TestOrderedNaN(t *testing.T) {
+	nan := math.NaN()
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "ratio", nan).ToBeGreaterOrEqual(0)
+	})
+	l.ExpectMessage(0).ToBe("ToBeGreaterOrEqual() can not order NaN, ratio is NaN compared to 0")
+
+	l = test.New(t, func(t expect.Test) {
+		expect.Value(t, "ratio", 0.5).Not().ToBeLessThan(nan)
+	})
+	l.ExpectMessage(0).ToBe("ToBeLessThan() can not order NaN, ratio is 0.5 compared to NaN")
+
+	l = test.New(t, func(t expect.Test) {
+		expect.Value(t, "ratio", float32(nan)).ToBeBetween(0, 1)
+	})
+	l.ExpectMessage(0).ToBe("ToBeBetween() can not order NaN, ratio is NaN with bounds 0 and 1")
+
+	l = test.New(t, func(t expect.Test) {
+		expect.ThatOrdered(t, "ratio", nan).ToBeLessOrEqual(1)
+	})
+	l.ExpectMessages().ToCount(1)
+}
@@ -0,0 +1,63 @@
+package expect_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/akabio/expect"
+	"github.com/akabio/expect/internal/test"
+)
+
+func explode() {
+	panic("boom")
+}
+
+func TestToPanic(t *testing.T) {
+	expect.Func(t, "explode", explode).ToPanic().ToBe("boom")
+	expect.Func(t, "explode", explode).ToPanicWith("boom")
+	expect.Func(t, "explode", explode).ToPanicMatching("^bo+m$")
+	expect.Func(t, "fail", func() error { panic(errors.New("bad state 7")) }).ToPanicMatching(`state \d`)
+	expect.Func(t, "noop", func() {}).NotToPanic()
+	expect.Func(t, "load", func() error { return nil }).NotToPanic().ToSucceed()
+	expect.Func(t, "load", func() error { return errors.New("missing") }).NotToPanic().Message().ToBe("missing")
+}
+
+func TestToPanicFail(t *testing.T) {
+	l := test.New(t, func(t expect.Test) {
+		expect.Func(t, "noop", func() {}).ToPanic()
+		expect.Func(t, "load", func() error { return errors.New("missing") }).ToPanicWith("boom")
+		expect.Func(t, "explode", explode).ToPanicWith("bang")
+		expect.Func(t, "explode", explode).ToPanicMatching("^bang")
+		expect.Func(t, "noop", func() {}).ToPanicMatching("^bang")
+	})
+	l.ExpectMessages().ToCount(5)
+	l.ExpectMessage(0).ToBe("expected noop to panic but it did not")
+	l.ExpectMessage(1).ToBe("expected load to panic with string 'boom' but it did not panic, it returned *errors.errorString 'missing'")
+	l.ExpectMessage(2).ToBe("expected panic of explode to be 'bang' but it is 'boom'")
+	l.ExpectMessage(3).ToBe("expected panic of explode to match '^bang' but it is 'boom'")
+	l.ExpectMessage(4).ToBe("expected noop to panic matching '^bang' but it did not panic")
+}
+
+func TestNotToPanicFail(t *testing.T) {
+	l := test.New(t, func(t expect.Test) {
+		expect.Func(t, "explode", explode).NotToPanic()
+		expect.Func(t, "index", func() {
+			list := []int{}
+			_ = list[len(list)]
+		}).NotToPanic()
+	})
+	l.ExpectMessages().ToCount(2)
+
+	first := strings.Split(l.Messages[0], "\n")
+	expect.Value(t, "first line", first[0]).ToBe("expected explode NOT to panic but it panicked with string 'boom'")
+	expect.Value(t, "stack", l.Messages[0]).ToContain("expect_test.explode()")
+	expect.Value(t, "second", l.Messages[1]).ToHavePrefix("expected index NOT to panic but it panicked with runtime.boundsError 'runtime error: index out of range [0] with length 0'")
+}
+
+func TestFuncType(t *testing.T) {
+	l := test.New(t, func(t expect.Test) {
+		expect.Func(t, "f", func() int { return 1 })
+	})
+	l.ExpectMessage(0).ToBe("Func must be called with a func() or func() error but it is func() int")
+}
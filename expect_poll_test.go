@@ -0,0 +1,148 @@
+package expect_test
+
+import (
+	"errors"
+	"io/fs"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/akabio/expect"
+	"github.com/akabio/expect/internal/test"
+)
+
+func TestEventually(t *testing.T) {
+	var counter int64
+
+	go func() {
+		for i := 0; i < 5; i++ {
+			time.Sleep(time.Millisecond)
+			atomic.AddInt64(&counter, 1)
+		}
+	}()
+
+	expect.Eventually(t, "counter", func() interface{} { return atomic.LoadInt64(&counter) }, time.Second, time.Millisecond).
+		ToBe(int64(5)).
+		ToBeGreaterOrEqual(5)
+
+	mu := sync.Mutex{}
+	names := []string{}
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		names = append(names, "gopher")
+		mu.Unlock()
+	}()
+
+	expect.Eventually(t, "names", func() interface{} {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]string{}, names...)
+	}, time.Second, time.Millisecond).ToContain("gopher").ToCount(1).Not().ToBeEmpty()
+}
+
+func TestEventuallyFail(t *testing.T) {
+	calls := 0
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Eventually(t, "state", func() interface{} {
+			calls++
+			return "starting"
+		}, 20*time.Millisecond, 5*time.Millisecond).ToBe("running")
+	})
+	l.ExpectMessages().ToCount(1)
+
+	lines := strings.Split(l.Messages[0], "\n")
+	expect.Value(t, "message", lines[0]).ToBe("expected state to be 'running' but it is 'starting'")
+	expect.Value(t, "attempts", lines[1]).ToMatch(`^gave up after \d+ attempts within 20ms, last value: 'starting'$`)
+	expect.Value(t, "calls", calls).ToBeBetween(2, 6)
+}
+
+func TestConsistently(t *testing.T) {
+	expect.Consistently(t, "state", func() interface{} { return "running" }, 10*time.Millisecond, time.Millisecond).
+		ToBe("running").
+		Not().ToBeEmpty()
+
+	n := 0
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Consistently(t, "connections", func() interface{} {
+			n++
+			return n
+		}, time.Second, time.Millisecond).ToBeLessThan(3)
+	})
+	l.ExpectMessages().ToCount(1)
+	l.ExpectMessage(0).ToBe("expected connections to be less than 3 but it is 3\nfailed on attempt 3 of polling for 1s, last value: 3")
+}
+
+func TestEventuallyRetriesTypeErrors(t *testing.T) {
+	// names is nil for the first two calls
+	names := func() func() interface{} {
+		calls := 0
+
+		return func() interface{} {
+			calls++
+			if calls < 3 {
+				return nil
+			}
+
+			return []string{"gopher"}
+		}
+	}
+
+	expect.Eventually(t, "names", names(), time.Second, time.Millisecond).ToContain("gopher")
+	expect.Eventually(t, "names", names(), time.Second, time.Millisecond).ToCount(1)
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Eventually(t, "names", func() interface{} { return nil }, 10*time.Millisecond, time.Millisecond).ToCount(1)
+	})
+	l.ExpectMessages().ToCount(1)
+
+	lines := strings.Split(l.Messages[0], "\n")
+	expect.Value(t, "message", lines[0]).ToBe("names is not a datatype with a length (array, slice, map, chan, string)")
+	expect.Value(t, "attempts", lines[1]).ToMatch(`^gave up after \d+ attempts within 10ms, last value: nil$`)
+}
+
+func TestEventuallyTo(t *testing.T) {
+	calls := 0
+
+	l := test.New(t, func(t expect.Test) {
+		var pathErr *fs.PathError
+
+		expect.Eventually(t, "err", func() interface{} {
+			calls++
+			if calls < 3 {
+				return errors.New("not ready")
+			}
+
+			return &fs.PathError{Op: "open", Path: "x", Err: fs.ErrNotExist}
+		}, time.Second, time.Millisecond).To(func(v expect.Val) expect.Val {
+			return v.ToBeErrorAs(&pathErr).Message().ToHavePrefix("open")
+		})
+
+		expect.Value(t, "path", pathErr.Path).ToBe("x")
+	})
+	l.ExpectMessages().ToCount(0)
+	expect.Value(t, "calls", calls).ToBe(3)
+}
+
+func TestEventuallyToFail(t *testing.T) {
+	l := test.New(t, func(t expect.Test) {
+		expect.Eventually(t, "name", func() interface{} { return "gopher" }, 10*time.Millisecond, time.Millisecond).
+			To(func(v expect.Val) expect.Val {
+				return v.ToHavePrefix("a").ToHaveSuffix("z")
+			})
+	})
+	l.ExpectMessages().ToCount(1)
+
+	lines := strings.Split(l.Messages[0], "\n")
+	expect.Value(t, "lines", lines[:2]).ToBe([]string{
+		"expected name to have prefix 'a' but it is 'gopher'",
+		"expected name to have suffix 'z' but it is 'gopher'",
+	})
+	expect.Value(t, "attempts", lines[2]).ToMatch(`^gave up after \d+ attempts within 10ms, last value: 'gopher'$`)
+}
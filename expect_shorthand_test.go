@@ -0,0 +1,114 @@
+package expect_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/akabio/expect"
+	"github.com/akabio/expect/internal/test"
+)
+
+func TestToBeNil(t *testing.T) {
+	var ptr *int
+
+	var fn func()
+
+	expect.Value(t, "nil", nil).ToBeNil()
+	expect.Value(t, "pointer", ptr).ToBeNil()
+	expect.Value(t, "func", fn).ToBeNil()
+	expect.Value(t, "map", map[string]int(nil)).ToBeNil()
+	expect.Value(t, "number", 0).ToBeNotNil()
+	expect.Value(t, "slice", []int{}).ToBeNotNil()
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "number", 3).ToBeNil()
+		expect.Value(t, "pointer", ptr).ToBeNotNil()
+	})
+	l.ExpectMessages().ToCount(2)
+	l.ExpectMessage(0).ToBe("expected number to be nil but it is 3")
+	l.ExpectMessage(1).ToBe("expected pointer NOT to be nil but it is")
+}
+
+func TestToBeEmpty(t *testing.T) {
+	expect.Value(t, "list", []int{}).ToBeEmpty()
+	expect.Value(t, "list", []int(nil)).ToBeEmpty()
+	expect.Value(t, "map", map[string]int{}).ToBeEmpty()
+	expect.Value(t, "name", "").ToBeEmpty()
+	expect.Value(t, "nil", nil).ToBeEmpty()
+	expect.Value(t, "struct", struct{ A int }{}).ToBeEmpty()
+	expect.Value(t, "name", "bob").Not().ToBeEmpty()
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "list", []int{1, 2}).ToBeEmpty()
+		expect.Value(t, "name", "bob").ToBeEmpty()
+		expect.Value(t, "count", 3).ToBeEmpty()
+		expect.Value(t, "list", []int{}).Not().ToBeEmpty()
+	})
+	l.ExpectMessages().ToCount(4)
+	l.ExpectMessage(0).ToBe("expected list to be empty but it has 2 elements")
+	l.ExpectMessage(1).ToBe("expected name to be empty but it is 'bob'")
+	l.ExpectMessage(2).ToBe("expected count to be empty but it is 3")
+	l.ExpectMessage(3).ToBe("expected list NOT to be empty but it is")
+}
+
+func TestToBeZero(t *testing.T) {
+	expect.Value(t, "count", 0).ToBeZero()
+	expect.Value(t, "time", time.Time{}).ToBeZero()
+	expect.Value(t, "struct", struct{ A string }{}).ToBeZero()
+	expect.Value(t, "list", []int{}).Not().ToBeZero()
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "count", 2).ToBeZero()
+		expect.Value(t, "name", "").Not().ToBeZero()
+	})
+	l.ExpectMessages().ToCount(2)
+	l.ExpectMessage(0).ToBe("expected count to be the zero value but it is 2")
+	l.ExpectMessage(1).ToBe("expected name NOT to be the zero value but it is")
+}
+
+func TestToBeTrueFalse(t *testing.T) {
+	type flag bool
+
+	expect.Value(t, "ok", true).ToBeTrue()
+	expect.Value(t, "ok", flag(false)).ToBeFalse()
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "ok", false).ToBeTrue()
+		expect.Value(t, "ok", true).ToBeFalse()
+		expect.Value(t, "ok", true).Not().ToBeTrue()
+	})
+	l.ExpectMessages().ToCount(3)
+	l.ExpectMessage(0).ToBe("expected ok to be true but it is false")
+	l.ExpectMessage(1).ToBe("expected ok to be false but it is true")
+	l.ExpectMessage(2).ToBe("expected ok NOT to be true but it is")
+
+	l = test.New(t, func(t expect.Test) {
+		expect.Value(t, "ok", 1).ToBeTrue()
+	})
+	l.ExpectMessage(0).ToBe("ToBeTrue must only be called on a bool value but it is int")
+}
+
+func TestToSucceed(t *testing.T) {
+	expect.Error(t, nil).ToSucceed()
+	expect.Error(t, errors.New("boom")).Not().ToSucceed()
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Error(t, fmt.Errorf("load: %w", &fs.PathError{Op: "open", Path: "x", Err: fs.ErrNotExist})).ToSucceed()
+		expect.Error(t, nil).Not().ToSucceed()
+	})
+	l.ExpectMessages().ToCount(2)
+	l.ExpectMessage(0).ToBe("expected error to succeed but it failed with *fmt.wrapError 'load: open x: file does not exist'")
+	l.ExpectMessage(1).ToBe("expected error NOT to succeed but it did")
+}
+
+func TestToSucceedTypedNil(t *testing.T) {
+	var pathErr *fs.PathError
+
+	var err error = pathErr
+
+	expect.Error(t, err).ToSucceed()
+	expect.Error(t, err).ToBeNil()
+}
@@ -24,6 +24,16 @@ func TestNilErrorMessage(t *testing.T) {
 	expect.Error(t, nil).Message().ToBe("")
 }
 
+func TestMessageOfNegatedIsNotNegated(t *testing.T) {
+	expect.Error(t, errors.New("boom")).Not().ToBe(nil).Message().ToBe("boom")
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Error(t, errors.New("boom")).Not().ToBe(nil).Message().ToBe("bang")
+	})
+	l.ExpectMessages().ToCount(1)
+	l.ExpectMessage(0).ToBe("expected error message to be 'bang' but it is 'boom'")
+}
+
 func TestIntToNotAllowMessageMethod(t *testing.T) {
 	l := test.New(t, func(t expect.Test) {
 		expect.Value(t, "int", 0).Message().ToBe("0")
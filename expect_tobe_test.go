@@ -73,12 +73,8 @@ func TestFailToBeMap(t *testing.T) {
 	l := test.New(t, func(t expect.Test) {
 		expect.Value(t, "names", map[string]int{"peter": 3, "johan": 2}).ToBe(map[string]int{"peter": 3, "johan": 1})
 	})
-	l.ExpectMessage(0).ToBe(`expected names to be
-    johan: 1
-    peter: 3
-but it is
-    johan: 2
-    peter: 3`)
+	l.ExpectMessage(0).ToBe(`expected names to be equal but it has 1 difference
+    names["johan"]: expected 1 but it is 2`)
 }
 
 type a string
@@ -102,16 +98,9 @@ func TestFailToBeWithDifferentMapSubtype(t *testing.T) {
 		expect.Value(t, "names", map[string]fmt.Stringer{"B": a("2"), "A": a("2")}).
 			ToBe(map[string]fmt.Stringer{"A": b(2), "B": b(2)})
 	})
-	l.ExpectMessage(0).ToBe(`expected names to be
-    (map[string]fmt.Stringer) (len=2) {
-      (string) (len=1) "A": (expect_test.b) 2,
-      (string) (len=1) "B": (expect_test.b) 2
-    }
-but it is
-    (map[string]fmt.Stringer) (len=2) {
-      (string) (len=1) "A": (expect_test.a) (len=1) 2,
-      (string) (len=1) "B": (expect_test.a) (len=1) 2
-    }`)
+	l.ExpectMessage(0).ToBe(`expected names to be equal but it has 2 differences
+    names["A"]: expected type expect_test.b but it is expect_test.a
+    names["B"]: expected type expect_test.b but it is expect_test.a`)
 }
 
 func TestToBeArray(t *testing.T) {
@@ -126,14 +115,85 @@ func TestFailToBeArray(t *testing.T) {
 	l := test.New(t, func(t expect.Test) {
 		expect.Value(t, "array", a).ToBe(b)
 	})
-	l.ExpectMessage(0).ToBe(`expected array to be
-    - a
-    - b
-    - s
-but it is
-    - a
-    - b
-    - c`)
+	l.ExpectMessage(0).ToBe(`expected array to be equal but it has 1 difference
+    array[2]: expected 's' but it is 'c'`)
+}
+
+type item struct {
+	Name  string
+	Price float64
+}
+
+type order struct {
+	ID     int
+	Items  []item
+	Labels map[string]string
+	Owner  *item
+}
+
+func TestFailToBeStructByPath(t *testing.T) {
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "order", order{
+			ID:     1,
+			Items:  []item{{"apple", 1.5}, {"pear", 4.0}},
+			Labels: map[string]string{"team": "a"},
+			Owner:  &item{Name: "peter"},
+		}).ToBe(order{
+			ID:     1,
+			Items:  []item{{"apple", 1.5}, {"pear", 4.5}, {"plum", 1}},
+			Labels: map[string]string{"env": "prod", "team": "a"},
+			Owner:  &item{Name: "paul"},
+		})
+	})
+	l.ExpectMessages().ToCount(1)
+	l.ExpectMessage(0).ToBe(`expected order to be equal but it has 4 differences
+    order.Items[1].Price: expected 4.5 but it is 4
+    order.Items[2]: missing
+    order.Labels["env"]: missing
+    order.Owner.Name: expected 'paul' but it is 'peter'`)
+}
+
+func TestFailToBeUnexpectedElements(t *testing.T) {
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "labels", map[string]int{"a": 1, "b": 2}).ToBe(map[string]int{"a": 1})
+		expect.Value(t, "list", []int{1, 2}).ToBe([]int{1})
+		expect.Value(t, "list", []int{}).ToBe([]int(nil))
+	})
+	l.ExpectMessage(0).ToBe(`expected labels to be equal but it has 1 difference
+    labels["b"]: unexpected 2`)
+	l.ExpectMessage(1).ToBe(`expected list to be equal but it has 1 difference
+    list[1]: unexpected 2`)
+	l.ExpectMessage(2).ToBe(`expected list to be equal but it has 1 difference
+    list: expected nil but it is []`)
+}
+
+func TestFailToBeLimitsDifferences(t *testing.T) {
+	l := test.New(t, func(t expect.Test) {
+		ex := &expect.Expect{MaxDifferences: 2}
+		ex.Value(t, "list", []int{1, 2, 3, 4}).ToBe([]int{5, 6, 7, 8})
+	})
+	l.ExpectMessage(0).ToBe(`expected list to be equal but it has 4 differences
+    list[0]: expected 5 but it is 1
+    list[1]: expected 6 but it is 2
+    ... and 2 more`)
+}
+
+func TestFailToBeCyclicStruct(t *testing.T) {
+	type node struct {
+		Name string
+		Next *node
+	}
+
+	a := &node{Name: "a"}
+	a.Next = a
+	b := &node{Name: "b"}
+	b.Next = b
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "node", a).ToBe(b)
+	})
+	l.ExpectMessage(0).ToBe(`expected node to be equal but it has 1 difference
+    node.Name: expected 'b' but it is 'a'`)
 }
 
 func TestNilTypeToBeNil(t *testing.T) {
@@ -0,0 +1,9 @@
+package expect
+
+// exports of internals for the tests in expect_test
+var (
+	RewriteInlineSnapshot = rewriteInlineSnapshot
+	UnusedSnapshotFiles   = unusedSnapshotFiles
+	PartialRun            = partialRun
+	ShortRun              = shortRun
+)
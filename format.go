@@ -44,6 +44,8 @@ func format(i interface{}) (string, presentation, bool) {
 		return fmt.Sprintf("%v", i), compact, false
 	case time.Time:
 		return t.Format(time.RFC3339Nano), compact, false
+	case time.Duration:
+		return t.String(), compact, false
 	case error:
 		return t.Error(), compact, true
 	}
@@ -131,3 +133,25 @@ func del(v string, d bool) string {
 
 	return v
 }
+
+// formatDifferences creates the message for values which differ in the listed places.
+func formatDifferences(name string, diffs []difference, more int) string {
+	total := len(diffs) + more
+
+	lines := make([]string, 0, len(diffs)+2)
+	if total == 1 {
+		lines = append(lines, fmt.Sprintf("expected %v to be equal but it has 1 difference", name))
+	} else {
+		lines = append(lines, fmt.Sprintf("expected %v to be equal but it has %v differences", name, total))
+	}
+
+	for _, d := range diffs {
+		lines = append(lines, "    "+d.String())
+	}
+
+	if more > 0 {
+		lines = append(lines, fmt.Sprintf("    ... and %v more", more))
+	}
+
+	return strings.Join(lines, "\n")
+}
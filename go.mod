@@ -1,12 +1,14 @@
 module github.com/akabio/expect
 
-go 1.18
+go 1.21
 
 require (
 	github.com/davecgh/go-spew v1.1.1
 	github.com/ghodss/yaml v1.0.0
 	github.com/sergi/go-diff v1.2.0
 	golang.org/x/exp v0.0.0-20230801115018-d63ba01acd4b
+	golang.org/x/image v0.11.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require gopkg.in/yaml.v2 v2.4.0 // indirect
@@ -0,0 +1,459 @@
+package expect
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"strings"
+)
+
+// pixelClass is the result of comparing a single pixel.
+type pixelClass uint8
+
+const (
+	pixelSame pixelClass = iota
+	// pixelTolerated differs but within the tolerance or because of anti-aliasing
+	pixelTolerated
+	pixelMismatch
+)
+
+// isSameImage compares the images, if they do not match it returns the reason and
+// an image visualizing the differences.
+func isSameImage(snapshot, current image.Image, opts *snapshotOptions) (bool, string, image.Image) {
+	snapshotSize := snapshot.Bounds().Size()
+	currentSize := current.Bounds().Size()
+
+	if snapshotSize != currentSize {
+		d := currentSize.Sub(snapshotSize)
+		if !opts.crop && (absInt(d.X) > opts.sizeTolerance || absInt(d.Y) > opts.sizeTolerance) {
+			return false, fmt.Sprintf("expected image size to be %v but it is %v, %v", snapshotSize, currentSize, describeSizeChange(d)),
+				sizeDiff(snapshot, current, opts)
+		}
+
+		// compare the overlapping area only
+		overlap := image.Pt(min(snapshotSize.X, currentSize.X), min(snapshotSize.Y, currentSize.Y))
+		snapshot = cropImage(snapshot, overlap)
+		current = cropImage(current, overlap)
+		snapshotSize = overlap
+	}
+
+	mask := newImageMask(snapshotSize, opts)
+	compared := snapshotSize.X * snapshotSize.Y
+
+	if mask != nil {
+		current = mask.apply(snapshot, current)
+		compared -= mask.count
+	}
+
+	diff := channelDiff
+	if opts.perceptual {
+		diff = perceptualDiff
+	}
+
+	mismatches := diff(snapshot, current, opts, nil)
+
+	// the pixels are only classified for the diff image when the comparison failed
+	diffImage := func() image.Image {
+		classes := make([]pixelClass, snapshotSize.X*snapshotSize.Y)
+		diff(snapshot, current, opts, classes)
+
+		return renderDiff(snapshot, classes, mask)
+	}
+
+	if opts.minSSIM > 0 {
+		score := ssim(toNRGBA(snapshot), toNRGBA(current))
+		if score < opts.minSSIM {
+			return false, fmt.Sprintf("expected image does not match snapshot, SSIM is %.4f but must be at least %.4f", score, opts.minSSIM),
+				diffImage()
+		}
+
+		return true, "", nil
+	}
+
+	m := 0.0
+	if compared > 0 {
+		m = float64(mismatches) / float64(compared)
+	}
+
+	if m > opts.matchTolerance {
+		return false, fmt.Sprintf("expected image does not match snapshot, %.1f%% of pixels do not match", m*100),
+			diffImage()
+	}
+
+	return true, "", nil
+}
+
+// channelDiff counts the pixels where the average difference of the channels is above the tolerance.
+// If classes is not nil the class of each pixel is stored in it.
+func channelDiff(snapshot, current image.Image, opts *snapshotOptions, classes []pixelClass) int {
+	size := snapshot.Bounds().Size()
+	snapshotPixel := newPixelReader(snapshot)
+	currentPixel := newPixelReader(current)
+
+	return parallelRows(size, func(y0, y1 int) int {
+		mismatches := 0
+
+		for y := y0; y < y1; y++ {
+			if sameRow(snapshot, current, y) {
+				continue
+			}
+
+			for x := 0; x < size.X; x++ {
+				rs, gs, bs, as := snapshotPixel(x, y)
+				rc, gc, bc, ac := currentPixel(x, y)
+
+				if rs == rc && gs == gc && bs == bc && as == ac {
+					continue
+				}
+
+				rd := getDiffFor(rs, rc)
+				gd := getDiffFor(gs, gc)
+				bd := getDiffFor(bs, bc)
+				ad := getDiffFor(as, ac)
+
+				class := pixelTolerated
+
+				avg := (rd + gd + bd + ad) / 4
+				if avg > opts.pixelTolerance {
+					class = pixelMismatch
+					mismatches++
+				}
+
+				if classes != nil {
+					classes[y*size.X+x] = class
+				}
+			}
+		}
+
+		return mismatches
+	})
+}
+
+func getDiffFor(rs, rc uint32) float64 {
+	return math.Abs(float64(rs)-float64(rc)) / (256*256 - 1)
+}
+
+// maxYIQDelta is the largest possible value of colorDelta.
+const maxYIQDelta = 35215
+
+// perceptualDiff counts the pixels with a perceived color difference above the tolerance, ignoring
+// differences caused by anti-aliasing. It follows the algorithm of the pixelmatch library.
+func perceptualDiff(snapshot, current image.Image, opts *snapshotOptions, classes []pixelClass) int {
+	s := toNRGBA(snapshot)
+	c := toNRGBA(current)
+
+	size := s.Bounds().Size()
+	maxDelta := maxYIQDelta * opts.pixelTolerance * opts.pixelTolerance
+
+	return parallelRows(size, func(y0, y1 int) int {
+		mismatches := 0
+
+		for y := y0; y < y1; y++ {
+			for x := 0; x < size.X; x++ {
+				delta := colorDelta(s, c, x, y, x, y, false)
+				if delta == 0 {
+					continue
+				}
+
+				class := pixelTolerated
+
+				if math.Abs(delta) > maxDelta && !antiAliased(s, c, x, y) && !antiAliased(c, s, x, y) {
+					class = pixelMismatch
+					mismatches++
+				}
+
+				if classes != nil {
+					classes[y*size.X+x] = class
+				}
+			}
+		}
+
+		return mismatches
+	})
+}
+
+// colorDelta calculates the color difference of two pixels in the YIQ color space. Transparent
+// pixels are blended with white. If yOnly is set only the difference in brightness is returned.
+// The result is negative if the first pixel is brighter.
+func colorDelta(a, b *image.NRGBA, ax, ay, bx, by int, yOnly bool) float64 {
+	pa := a.PixOffset(ax, ay)
+	pb := b.PixOffset(bx, by)
+
+	ca := a.Pix[pa : pa+4 : pa+4]
+	cb := b.Pix[pb : pb+4 : pb+4]
+
+	if ca[0] == cb[0] && ca[1] == cb[1] && ca[2] == cb[2] && ca[3] == cb[3] {
+		return 0
+	}
+
+	r1, g1, b1 := blendWhite(ca)
+	r2, g2, b2 := blendWhite(cb)
+
+	y1 := rgb2y(r1, g1, b1)
+	y2 := rgb2y(r2, g2, b2)
+	dy := y1 - y2
+
+	if yOnly {
+		return dy
+	}
+
+	di := rgb2i(r1, g1, b1) - rgb2i(r2, g2, b2)
+	dq := rgb2q(r1, g1, b1) - rgb2q(r2, g2, b2)
+
+	delta := 0.5053*dy*dy + 0.299*di*di + 0.1957*dq*dq
+	if y1 > y2 {
+		return -delta
+	}
+
+	return delta
+}
+
+func blendWhite(c []uint8) (float64, float64, float64) {
+	r, g, b := float64(c[0]), float64(c[1]), float64(c[2])
+	if c[3] == 255 {
+		return r, g, b
+	}
+
+	a := float64(c[3]) / 255
+
+	return 255 + (r-255)*a, 255 + (g-255)*a, 255 + (b-255)*a
+}
+
+func rgb2y(r, g, b float64) float64 { return r*0.29889531 + g*0.58662247 + b*0.11448223 }
+func rgb2i(r, g, b float64) float64 { return r*0.59597799 - g*0.27417610 - b*0.32180189 }
+func rgb2q(r, g, b float64) float64 { return r*0.21147017 - g*0.52261711 + b*0.31114694 }
+
+// antiAliased checks if the pixel at x, y of img is likely part of an anti-aliased edge. That is
+// the case if its brightness is between the one of its darkest and brightest neighbor and
+// these neighbors are part of a larger area of the same color in both images.
+func antiAliased(img, other *image.NRGBA, x1, y1 int) bool {
+	size := img.Bounds().Size()
+	x0, y0 := max(x1-1, 0), max(y1-1, 0)
+	x2, y2 := min(x1+1, size.X-1), min(y1+1, size.Y-1)
+
+	zeroes := 0
+	if x1 == x0 || x1 == x2 || y1 == y0 || y1 == y2 {
+		zeroes = 1
+	}
+
+	min, max := 0.0, 0.0
+	minX, minY, maxX, maxY := 0, 0, 0, 0
+
+	for x := x0; x <= x2; x++ {
+		for y := y0; y <= y2; y++ {
+			if x == x1 && y == y1 {
+				continue
+			}
+
+			delta := colorDelta(img, img, x1, y1, x, y, true)
+
+			switch {
+			case delta == 0:
+				zeroes++
+				if zeroes > 2 {
+					return false
+				}
+			case delta < min:
+				min, minX, minY = delta, x, y
+			case delta > max:
+				max, maxX, maxY = delta, x, y
+			}
+		}
+	}
+
+	if min == 0 || max == 0 {
+		return false
+	}
+
+	return (hasManySiblings(img, minX, minY) && hasManySiblings(other, minX, minY)) ||
+		(hasManySiblings(img, maxX, maxY) && hasManySiblings(other, maxX, maxY))
+}
+
+// hasManySiblings checks if more than two neighbors of the pixel have the same color.
+func hasManySiblings(img *image.NRGBA, x1, y1 int) bool {
+	size := img.Bounds().Size()
+	x0, y0 := max(x1-1, 0), max(y1-1, 0)
+	x2, y2 := min(x1+1, size.X-1), min(y1+1, size.Y-1)
+
+	zeroes := 0
+	if x1 == x0 || x1 == x2 || y1 == y0 || y1 == y2 {
+		zeroes = 1
+	}
+
+	p := img.PixOffset(x1, y1)
+
+	for x := x0; x <= x2; x++ {
+		for y := y0; y <= y2; y++ {
+			if x == x1 && y == y1 {
+				continue
+			}
+
+			o := img.PixOffset(x, y)
+			if img.Pix[p] == img.Pix[o] && img.Pix[p+1] == img.Pix[o+1] && img.Pix[p+2] == img.Pix[o+2] && img.Pix[p+3] == img.Pix[o+3] {
+				zeroes++
+			}
+
+			if zeroes > 2 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ssimWindow is the size of the square windows the SSIM is calculated for.
+const ssimWindow = 8
+
+// ssim calculates the mean structural similarity index of the brightness of both images.
+// It's calculated for windows of 8x8 pixels which overlap by half their size.
+func ssim(a, b *image.NRGBA) float64 {
+	size := a.Bounds().Size()
+	w := min(ssimWindow, min(size.X, size.Y))
+
+	if w == 0 {
+		return 1
+	}
+
+	step := max(1, w/2)
+
+	const (
+		c1 = (0.01 * 255) * (0.01 * 255)
+		c2 = (0.03 * 255) * (0.03 * 255)
+	)
+
+	lum := func(img *image.NRGBA, x, y int) float64 {
+		p := img.PixOffset(x, y)
+		r, g, b := blendWhite(img.Pix[p : p+4])
+
+		return rgb2y(r, g, b)
+	}
+
+	total := 0.0
+	windows := 0
+
+	for wy := 0; wy+w <= size.Y; wy += step {
+		for wx := 0; wx+w <= size.X; wx += step {
+			var sumA, sumB, sumAA, sumBB, sumAB float64
+
+			for y := wy; y < wy+w; y++ {
+				for x := wx; x < wx+w; x++ {
+					la := lum(a, x, y)
+					lb := lum(b, x, y)
+					sumA += la
+					sumB += lb
+					sumAA += la * la
+					sumBB += lb * lb
+					sumAB += la * lb
+				}
+			}
+
+			n := float64(w * w)
+			meanA := sumA / n
+			meanB := sumB / n
+			varA := sumAA/n - meanA*meanA
+			varB := sumBB/n - meanB*meanB
+			cov := sumAB/n - meanA*meanB
+
+			total += ((2*meanA*meanB + c1) * (2*cov + c2)) / ((meanA*meanA + meanB*meanB + c1) * (varA + varB + c2))
+			windows++
+		}
+	}
+
+	return total / float64(windows)
+}
+
+// toNRGBA returns the image as *image.NRGBA with bounds starting at 0, 0.
+func toNRGBA(img image.Image) *image.NRGBA {
+	if n, is := img.(*image.NRGBA); is && n.Bounds().Min == (image.Point{}) {
+		return n
+	}
+
+	b := img.Bounds()
+	n := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(n, n.Bounds(), img, b.Min, draw.Src)
+
+	return n
+}
+
+// describeSizeChange describes how the edges of the image moved, images are aligned at the top left corner.
+func describeSizeChange(d image.Point) string {
+	changes := []string{}
+
+	edge := func(name string, px int) {
+		switch {
+		case px > 0:
+			changes = append(changes, fmt.Sprintf("the %v edge grew by %vpx", name, px))
+		case px < 0:
+			changes = append(changes, fmt.Sprintf("the %v edge shrank by %vpx", name, -px))
+		}
+	}
+
+	edge("right", d.X)
+	edge("bottom", d.Y)
+
+	return strings.Join(changes, " and ")
+}
+
+// sizeDiff renders the diff of images with different sizes. Both are padded to the size of the union
+// with a checkerboard, all pixels which are not part of both images count as mismatch.
+func sizeDiff(snapshot, current image.Image, opts *snapshotOptions) image.Image {
+	ss := snapshot.Bounds().Size()
+	cs := current.Bounds().Size()
+	size := image.Pt(max(ss.X, cs.X), max(ss.Y, cs.Y))
+
+	ps := padImage(snapshot, size)
+	pc := padImage(current, size)
+
+	classes := make([]pixelClass, size.X*size.Y)
+	channelDiff(ps, pc, opts, classes)
+
+	for y := 0; y < size.Y; y++ {
+		for x := 0; x < size.X; x++ {
+			if x >= ss.X || y >= ss.Y || x >= cs.X || y >= cs.Y {
+				classes[y*size.X+x] = pixelMismatch
+			}
+		}
+	}
+
+	return renderDiff(ps, classes, nil)
+}
+
+// checkerSize is the size of the squares of the padding checkerboard.
+const checkerSize = 8
+
+var checkerColors = [2]color.RGBA{{R: 255, G: 255, B: 255, A: 255}, {R: 204, G: 204, B: 204, A: 255}}
+
+// padImage draws the image at the top left of a checkerboard of the given size.
+func padImage(img image.Image, size image.Point) *image.RGBA {
+	out := image.NewRGBA(image.Rect(0, 0, size.X, size.Y))
+
+	for y := 0; y < size.Y; y++ {
+		for x := 0; x < size.X; x++ {
+			out.SetRGBA(x, y, checkerColors[(x/checkerSize+y/checkerSize)%2])
+		}
+	}
+
+	b := img.Bounds()
+	draw.Draw(out, image.Rect(0, 0, b.Dx(), b.Dy()), img, b.Min, draw.Src)
+
+	return out
+}
+
+// cropImage returns the top left area of the given size with bounds starting at 0, 0.
+func cropImage(img image.Image, size image.Point) image.Image {
+	out := image.NewRGBA64(image.Rect(0, 0, size.X, size.Y))
+	draw.Draw(out, out.Bounds(), img, img.Bounds().Min, draw.Src)
+
+	return out
+}
+
+func absInt(i int) int {
+	if i < 0 {
+		return -i
+	}
+
+	return i
+}
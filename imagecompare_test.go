@@ -0,0 +1,39 @@
+package expect
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestAntiAliasedEdge(t *testing.T) {
+	// a black square on white with a gray pixel on its edge
+	img := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	other := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			c := color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+			if x < 4 {
+				c = color.NRGBA{A: 255}
+			}
+
+			img.SetNRGBA(x, y, c)
+			other.SetNRGBA(x, y, c)
+		}
+	}
+
+	img.SetNRGBA(4, 4, color.NRGBA{R: 128, G: 128, B: 128, A: 255})
+
+	Value(t, "edge pixel", antiAliased(img, other, 4, 4)).ToBe(true)
+	Value(t, "inner pixel", antiAliased(img, other, 6, 4)).ToBe(false)
+}
+
+func TestSSIMOfSameImage(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 16, 16))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(i * 7)
+	}
+
+	Value(t, "ssim", ssim(img, img)).ToBeAbout(1, 0.000001)
+}
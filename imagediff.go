@@ -0,0 +1,144 @@
+package expect
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+var (
+	mismatchColor  = color.RGBA{R: 255, A: 255}
+	toleratedColor = color.RGBA{R: 255, G: 200, A: 255}
+	clusterColor   = color.RGBA{R: 255, B: 255, A: 255}
+	separatorColor = color.RGBA{R: 128, G: 128, B: 128, A: 255}
+)
+
+const (
+	// clusterDistance is the maximum distance of mismatching pixels to be part of the same cluster
+	clusterDistance = 3
+	// clusterMargin is the space between the cluster and its outline
+	clusterMargin = 2
+	// compareGap is the width of the separator in the compare image
+	compareGap = 4
+)
+
+// renderDiff draws the snapshot desaturated and faded, the differing pixels highlighted, masked
+// areas hatched and each cluster of mismatching pixels outlined.
+func renderDiff(snapshot image.Image, classes []pixelClass, mask *imageMask) *image.RGBA {
+	s := toNRGBA(snapshot)
+	size := s.Bounds().Size()
+	diff := image.NewRGBA(image.Rect(0, 0, size.X, size.Y))
+
+	for y := 0; y < size.Y; y++ {
+		for x := 0; x < size.X; x++ {
+			switch classes[y*size.X+x] {
+			case pixelMismatch:
+				diff.SetRGBA(x, y, mismatchColor)
+			case pixelTolerated:
+				diff.SetRGBA(x, y, toleratedColor)
+			default:
+				p := s.PixOffset(x, y)
+				r, g, b := blendWhite(s.Pix[p : p+4])
+				v := uint8(255 - (255-rgb2y(r, g, b))*0.3)
+				diff.SetRGBA(x, y, color.RGBA{R: v, G: v, B: v, A: 255})
+			}
+		}
+	}
+
+	if mask != nil {
+		mask.hatch(diff)
+	}
+
+	for _, c := range mismatchClusters(classes, size) {
+		outline(diff, c.Inset(-clusterMargin), clusterColor)
+	}
+
+	return diff
+}
+
+// mismatchClusters groups mismatching pixels which are close to each other and returns the
+// bounding box of each group.
+func mismatchClusters(classes []pixelClass, size image.Point) []image.Rectangle {
+	visited := make([]bool, len(classes))
+	clusters := []image.Rectangle{}
+
+	for start, c := range classes {
+		if c != pixelMismatch || visited[start] {
+			continue
+		}
+
+		visited[start] = true
+		stack := []int{start}
+		box := image.Rectangle{}
+
+		for len(stack) > 0 {
+			i := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			x, y := i%size.X, i/size.X
+			box = box.Union(image.Rect(x, y, x+1, y+1))
+
+			for ny := max(0, y-clusterDistance); ny <= min(size.Y-1, y+clusterDistance); ny++ {
+				for nx := max(0, x-clusterDistance); nx <= min(size.X-1, x+clusterDistance); nx++ {
+					n := ny*size.X + nx
+					if classes[n] == pixelMismatch && !visited[n] {
+						visited[n] = true
+						stack = append(stack, n)
+					}
+				}
+			}
+		}
+
+		clusters = append(clusters, box)
+	}
+
+	return clusters
+}
+
+// outline draws a one pixel border along the inside of r, clipped to the image.
+func outline(img *image.RGBA, r image.Rectangle, c color.RGBA) {
+	b := img.Bounds()
+
+	for x := r.Min.X; x < r.Max.X; x++ {
+		for _, y := range []int{r.Min.Y, r.Max.Y - 1} {
+			if (image.Point{X: x, Y: y}).In(b) {
+				img.SetRGBA(x, y, c)
+			}
+		}
+	}
+
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for _, x := range []int{r.Min.X, r.Max.X - 1} {
+			if (image.Point{X: x, Y: y}).In(b) {
+				img.SetRGBA(x, y, c)
+			}
+		}
+	}
+}
+
+// compareImage places the images side by side, separated by a gray bar.
+func compareImage(images ...image.Image) *image.RGBA {
+	width, height := 0, 0
+
+	for i, img := range images {
+		if i > 0 {
+			width += compareGap
+		}
+
+		width += img.Bounds().Dx()
+		height = max(height, img.Bounds().Dy())
+	}
+
+	out := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(out, out.Bounds(), image.NewUniform(separatorColor), image.Point{}, draw.Src)
+
+	x := 0
+
+	for _, img := range images {
+		b := img.Bounds()
+		draw.Draw(out, image.Rect(x, 0, x+b.Dx(), b.Dy()), img, b.Min, draw.Src)
+		x += b.Dx() + compareGap
+	}
+
+	return out
+}
@@ -0,0 +1,46 @@
+package expect
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestMismatchClusters(t *testing.T) {
+	size := image.Pt(20, 10)
+	classes := make([]pixelClass, size.X*size.Y)
+
+	set := func(x, y int, c pixelClass) {
+		classes[y*size.X+x] = c
+	}
+
+	// two pixels close to each other form one cluster
+	set(1, 1, pixelMismatch)
+	set(3, 2, pixelMismatch)
+	// a tolerated pixel between does not join the clusters
+	set(8, 2, pixelTolerated)
+	set(15, 8, pixelMismatch)
+
+	Value(t, "clusters", mismatchClusters(classes, size)).ToBe([]image.Rectangle{
+		image.Rect(1, 1, 4, 3),
+		image.Rect(15, 8, 16, 9),
+	})
+}
+
+func TestRenderDiff(t *testing.T) {
+	snapshot := image.NewNRGBA(image.Rect(0, 0, 20, 20))
+	for i := range snapshot.Pix {
+		snapshot.Pix[i] = 255
+	}
+
+	classes := make([]pixelClass, 20*20)
+	classes[10*20+10] = pixelMismatch
+	classes[0] = pixelTolerated
+
+	diff := renderDiff(snapshot, classes, nil)
+
+	Value(t, "mismatch", diff.RGBAAt(10, 10)).ToBe(mismatchColor)
+	Value(t, "tolerated", diff.RGBAAt(0, 0)).ToBe(toleratedColor)
+	Value(t, "outline", diff.RGBAAt(8, 8)).ToBe(clusterColor)
+	Value(t, "background", diff.RGBAAt(5, 5)).ToBe(color.RGBA{R: 255, G: 255, B: 255, A: 255})
+}
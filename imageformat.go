@@ -0,0 +1,85 @@
+package expect
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"path/filepath"
+	"strings"
+
+	// decoders for image values given as []byte
+	_ "image/gif"
+	_ "image/jpeg"
+
+	"github.com/akabio/expect/internal/imageext"
+	"golang.org/x/image/bmp"
+	"golang.org/x/image/tiff"
+)
+
+// imageEncoder writes an image in a lossless format.
+type imageEncoder func(w io.Writer, img image.Image, opts *snapshotOptions) error
+
+// imageEncoders are the encoders of all imageext.Extensions.
+var imageEncoders = map[string]imageEncoder{
+	".png":  encodePNG,
+	".bmp":  encodeBMP,
+	".tif":  encodeTIFF,
+	".tiff": encodeTIFF,
+}
+
+func encodePNG(w io.Writer, img image.Image, opts *snapshotOptions) error {
+	enc := &png.Encoder{CompressionLevel: opts.pngCompression}
+	return enc.Encode(w, img)
+}
+
+func encodeBMP(w io.Writer, img image.Image, opts *snapshotOptions) error {
+	if is16Bit(img) {
+		return fmt.Errorf("bmp can not store 16-bit images without loss, use a .png or .tiff snapshot")
+	}
+
+	// the bmp decoder ignores the alpha channel
+	if o, ok := img.(interface{ Opaque() bool }); !ok || !o.Opaque() {
+		return fmt.Errorf("bmp can not store transparent images without loss, use a .png or .tiff snapshot")
+	}
+
+	return bmp.Encode(w, img)
+}
+
+func encodeTIFF(w io.Writer, img image.Image, opts *snapshotOptions) error {
+	return tiff.Encode(w, img, &tiff.Options{Compression: tiff.Deflate})
+}
+
+// is16Bit returns true for images with more than 8 bits per channel.
+func is16Bit(img image.Image) bool {
+	switch img.ColorModel() {
+	case color.RGBA64Model, color.NRGBA64Model, color.Gray16Model, color.Alpha16Model:
+		return true
+	}
+
+	return false
+}
+
+// imageExtension returns the extension of a supported image snapshot path or "" if it is not supported.
+func imageExtension(path string) string {
+	ext := strings.ToLower(filepath.Ext(path))
+	if _, has := imageEncoders[ext]; has {
+		return ext
+	}
+
+	return ""
+}
+
+// imageArtifactSuffixes are the suffixes of all files written next to a mismatching image snapshot.
+func imageArtifactSuffixes() []string {
+	suffixes := []string{}
+
+	for _, ext := range imageext.Extensions {
+		for _, kind := range []string{".current", ".diff", ".compare"} {
+			suffixes = append(suffixes, kind+ext)
+		}
+	}
+
+	return suffixes
+}
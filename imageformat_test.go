@@ -0,0 +1,107 @@
+package expect_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/png"
+	"os"
+	"testing"
+
+	"github.com/akabio/expect"
+	"github.com/akabio/expect/internal/test"
+	"golang.org/x/image/bmp"
+)
+
+func gradient16() *image.RGBA64 {
+	img := image.NewRGBA64(image.Rect(0, 0, 32, 32))
+
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 32; x++ {
+			img.SetRGBA64(x, y, color.RGBA64{R: uint16(x*2000 + y), G: uint16(y * 2000), B: 0x1234, A: 0xffff})
+		}
+	}
+
+	return img
+}
+
+func readImage(t *testing.T, path string) image.Image {
+	data, err := os.ReadFile(path)
+	expect.Error(t, err).ToBe(nil)
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	expect.Error(t, err).ToBe(nil)
+
+	return img
+}
+
+func TestSnapshotImageTIFF16Bit(t *testing.T) {
+	cleanTestData(t)
+
+	img := gradient16()
+	expect.Value(t, "content", img).ToBeSnapshotImage("testdata/volatile/gradient.tiff", expect.WithExact())
+	expect.Value(t, "content", img).ToBeSnapshotImage("testdata/volatile/gradient.tiff", expect.WithExact())
+
+	stored := readImage(t, "testdata/volatile/gradient.tiff")
+	expect.Value(t, "pixel", stored.At(5, 7)).ToBe(color.Color(img.At(5, 7)))
+}
+
+func TestSnapshotImagePNGPaletted(t *testing.T) {
+	cleanTestData(t)
+
+	img := image.NewPaletted(image.Rect(0, 0, 16, 16), palette.Plan9)
+	img.SetColorIndex(3, 4, 100)
+
+	expect.Value(t, "content", img).ToBeSnapshotImage("testdata/volatile/paletted.png", expect.WithPNGCompression(png.BestCompression))
+
+	stored := readImage(t, "testdata/volatile/paletted.png")
+	expect.Value(t, "type", stored).ToBeType(&image.Paletted{})
+	expect.Value(t, "pixel", stored.At(3, 4)).ToBe(palette.Plan9[100])
+}
+
+func TestSnapshotImageBMP(t *testing.T) {
+	cleanTestData(t)
+
+	opaque := image.NewRGBA(sampleImage.Bounds())
+	draw.Draw(opaque, opaque.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(opaque, opaque.Bounds(), sampleImage, image.Point{}, draw.Over)
+
+	encoded := bytes.NewBuffer(nil)
+	err := bmp.Encode(encoded, opaque)
+	expect.Error(t, err).ToBe(nil)
+
+	expect.Value(t, "content", encoded.Bytes()).ToBeSnapshotImage("testdata/volatile/sample.bmp", expect.WithExact())
+	expect.Value(t, "content", opaque).ToBeSnapshotImage("testdata/volatile/sample.bmp", expect.WithExact())
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "content", gradient16()).ToBeSnapshotImage("testdata/volatile/gradient.bmp")
+	})
+	l.ExpectMessage(0).ToBe("failed to write snapshot testdata/volatile/gradient.bmp, bmp can not store 16-bit images without loss, use a .png or .tiff snapshot")
+
+	l = test.New(t, func(t expect.Test) {
+		expect.Value(t, "content", sampleImage).ToBeSnapshotImage("testdata/volatile/transparent.bmp")
+	})
+	l.ExpectMessage(0).ToBe("failed to write snapshot testdata/volatile/transparent.bmp, bmp can not store transparent images without loss, use a .png or .tiff snapshot")
+}
+
+func TestSnapshotImageArtifactFormat(t *testing.T) {
+	cleanTestData(t)
+
+	expect.Value(t, "content", gradient16()).ToBeSnapshotImage("testdata/volatile/gradient.tif")
+
+	test.New(t, func(t expect.Test) {
+		expect.Value(t, "content", sampleImage).ToBeSnapshotImage("testdata/volatile/gradient.tif")
+	})
+
+	readImage(t, "testdata/volatile/gradient.current.tif")
+	readImage(t, "testdata/volatile/gradient.diff.tif")
+}
+
+func TestSnapshotImageUnsupportedFormat(t *testing.T) {
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "content", sampleImage).ToBeSnapshotImage("testdata/volatile/sample.jpg")
+	})
+	l.ExpectMessage(0).ToBe("unsupported image snapshot format .jpg, use one of .png, .bmp, .tif, .tiff")
+}
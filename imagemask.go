@@ -0,0 +1,95 @@
+package expect
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// imageMask marks the pixels which are excluded from the comparison.
+type imageMask struct {
+	size   image.Point
+	masked []bool
+	count  int
+}
+
+var hatchColor = color.RGBA{R: 64, G: 96, B: 255, A: 255}
+
+// newImageMask creates the mask from the ignored regions and mask images of the options.
+// Returns nil if nothing is masked.
+func newImageMask(size image.Point, opts *snapshotOptions) *imageMask {
+	if len(opts.ignoreRegions) == 0 && len(opts.masks) == 0 {
+		return nil
+	}
+
+	m := &imageMask{size: size, masked: make([]bool, size.X*size.Y)}
+	bounds := image.Rect(0, 0, size.X, size.Y)
+
+	for _, r := range opts.ignoreRegions {
+		r = r.Intersect(bounds)
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			for x := r.Min.X; x < r.Max.X; x++ {
+				m.set(x, y)
+			}
+		}
+	}
+
+	for _, mi := range opts.masks {
+		mb := mi.Bounds()
+		r := image.Rect(0, 0, mb.Dx(), mb.Dy()).Intersect(bounds)
+
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			for x := r.Min.X; x < r.Max.X; x++ {
+				_, _, _, a := mi.At(mb.Min.X+x, mb.Min.Y+y).RGBA()
+				if a > 0 {
+					m.set(x, y)
+				}
+			}
+		}
+	}
+
+	return m
+}
+
+func (m *imageMask) set(x, y int) {
+	i := y*m.size.X + x
+	if !m.masked[i] {
+		m.masked[i] = true
+		m.count++
+	}
+}
+
+func (m *imageMask) isMasked(x, y int) bool {
+	return m.masked[y*m.size.X+x]
+}
+
+// apply returns a copy of current where all masked pixels are replaced by the ones of the
+// snapshot, so they never count as difference.
+func (m *imageMask) apply(snapshot, current image.Image) image.Image {
+	sb := snapshot.Bounds()
+	cb := current.Bounds()
+
+	out := image.NewRGBA64(image.Rect(0, 0, m.size.X, m.size.Y))
+	draw.Draw(out, out.Bounds(), current, cb.Min, draw.Src)
+
+	for y := 0; y < m.size.Y; y++ {
+		for x := 0; x < m.size.X; x++ {
+			if m.isMasked(x, y) {
+				out.Set(x, y, snapshot.At(sb.Min.X+x, sb.Min.Y+y))
+			}
+		}
+	}
+
+	return out
+}
+
+// hatch draws diagonal stripes over the masked pixels of the diff image.
+func (m *imageMask) hatch(img *image.RGBA) {
+	for y := 0; y < m.size.Y; y++ {
+		for x := 0; x < m.size.X; x++ {
+			if m.isMasked(x, y) && (x+y)%6 < 2 {
+				img.SetRGBA(x, y, hatchColor)
+			}
+		}
+	}
+}
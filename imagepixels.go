@@ -0,0 +1,123 @@
+package expect
+
+import (
+	"bytes"
+	"image"
+	"reflect"
+	"runtime"
+	"sync"
+)
+
+// minParallelPixels is the image size from which rows are compared concurrently.
+const minParallelPixels = 64 * 1024
+
+// pixelReader returns the premultiplied 16-bit color of the pixel at x, y relative to the top left
+// of the image, like At(x, y).RGBA() but without the allocation of a color.
+type pixelReader func(x, y int) (r, g, b, a uint32)
+
+// newPixelReader returns a reader which accesses Pix directly for the common image types.
+func newPixelReader(img image.Image) pixelReader {
+	min := img.Bounds().Min
+
+	switch i := img.(type) {
+	case *image.RGBA:
+		return func(x, y int) (uint32, uint32, uint32, uint32) {
+			p := i.Pix[i.PixOffset(min.X+x, min.Y+y):]
+			r, g, b, a := uint32(p[0]), uint32(p[1]), uint32(p[2]), uint32(p[3])
+
+			return r | r<<8, g | g<<8, b | b<<8, a | a<<8
+		}
+
+	case *image.NRGBA:
+		return func(x, y int) (uint32, uint32, uint32, uint32) {
+			p := i.Pix[i.PixOffset(min.X+x, min.Y+y):]
+			r, g, b, a := uint32(p[0]), uint32(p[1]), uint32(p[2]), uint32(p[3])
+			a |= a << 8
+
+			// premultiply like color.NRGBA does
+			return (r | r<<8) * a / 0xffff, (g | g<<8) * a / 0xffff, (b | b<<8) * a / 0xffff, a
+		}
+
+	case *image.RGBA64:
+		return func(x, y int) (uint32, uint32, uint32, uint32) {
+			p := i.Pix[i.PixOffset(min.X+x, min.Y+y):]
+
+			return uint32(p[0])<<8 | uint32(p[1]), uint32(p[2])<<8 | uint32(p[3]),
+				uint32(p[4])<<8 | uint32(p[5]), uint32(p[6])<<8 | uint32(p[7])
+		}
+	}
+
+	return func(x, y int) (uint32, uint32, uint32, uint32) {
+		return img.At(min.X+x, min.Y+y).RGBA()
+	}
+}
+
+// pixRow returns the raw bytes of row y relative to the top left of the image or nil if the type
+// of the image has no direct pixel access.
+func pixRow(img image.Image, y int) []byte {
+	b := img.Bounds()
+
+	switch i := img.(type) {
+	case *image.RGBA:
+		o := i.PixOffset(b.Min.X, b.Min.Y+y)
+		return i.Pix[o : o+b.Dx()*4]
+	case *image.NRGBA:
+		o := i.PixOffset(b.Min.X, b.Min.Y+y)
+		return i.Pix[o : o+b.Dx()*4]
+	case *image.RGBA64:
+		o := i.PixOffset(b.Min.X, b.Min.Y+y)
+		return i.Pix[o : o+b.Dx()*8]
+	}
+
+	return nil
+}
+
+// sameRow returns true if both images have the same type and row y has the same bytes.
+func sameRow(a, b image.Image, y int) bool {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return false
+	}
+
+	ra := pixRow(a, y)
+
+	return ra != nil && bytes.Equal(ra, pixRow(b, y))
+}
+
+// parallelRows splits the rows of an image of the given size in bands which are processed
+// concurrently by f and returns the sum of the results. Small images are processed in one band.
+func parallelRows(size image.Point, f func(y0, y1 int) int) int {
+	workers := runtime.GOMAXPROCS(0)
+	if workers > size.Y {
+		workers = size.Y
+	}
+
+	if workers <= 1 || size.X*size.Y < minParallelPixels {
+		return f(0, size.Y)
+	}
+
+	results := make([]int, workers)
+	band := (size.Y + workers - 1) / workers
+
+	wg := sync.WaitGroup{}
+
+	for w := 0; w < workers; w++ {
+		y0 := w * band
+		y1 := min(y0+band, size.Y)
+
+		wg.Add(1)
+
+		go func(w, y0, y1 int) {
+			defer wg.Done()
+			results[w] = f(y0, y1)
+		}(w, y0, y1)
+	}
+
+	wg.Wait()
+
+	total := 0
+	for _, r := range results {
+		total += r
+	}
+
+	return total
+}
@@ -0,0 +1,135 @@
+package expect
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math/rand"
+	"testing"
+)
+
+// noiseImage creates an image with random colors, the bounds start at min.
+func noiseImage(min image.Point, size image.Point, seed int64) *image.NRGBA {
+	r := rand.New(rand.NewSource(seed))
+	img := image.NewNRGBA(image.Rectangle{Min: min, Max: min.Add(size)})
+	r.Read(img.Pix)
+
+	return img
+}
+
+func TestPixelReader(t *testing.T) {
+	src := noiseImage(image.Pt(3, 5), image.Pt(20, 10), 1)
+
+	rgba := image.NewRGBA(src.Bounds())
+	draw.Draw(rgba, rgba.Bounds(), src, src.Bounds().Min, draw.Src)
+
+	rgba64 := image.NewRGBA64(src.Bounds())
+	draw.Draw(rgba64, rgba64.Bounds(), src, src.Bounds().Min, draw.Src)
+
+	gray := image.NewGray(src.Bounds())
+	draw.Draw(gray, gray.Bounds(), src, src.Bounds().Min, draw.Src)
+
+	for _, img := range []image.Image{src, rgba, rgba64, gray} {
+		read := newPixelReader(img)
+
+		for y := 0; y < 10; y++ {
+			for x := 0; x < 20; x++ {
+				r, g, b, a := read(x, y)
+				Value(t, "pixel", color.RGBA64{uint16(r), uint16(g), uint16(b), uint16(a)}).
+					ToBe(color.RGBA64Model.Convert(img.At(x+3, y+5)))
+			}
+		}
+	}
+}
+
+func TestParallelChannelDiff(t *testing.T) {
+	snapshot := noiseImage(image.Point{}, image.Pt(400, 300), 1)
+	current := image.NewNRGBA(snapshot.Bounds())
+	copy(current.Pix, snapshot.Pix)
+
+	for i := 0; i < 1000; i++ {
+		current.Pix[i*401] ^= 0xff
+	}
+
+	opts := newSnapshotOptions(nil)
+
+	classes := make([]pixelClass, 400*300)
+	mismatches := channelDiff(snapshot, current, opts, classes)
+
+	serial := 0
+
+	for y := 0; y < 300; y++ {
+		serial += channelDiff(snapshot.SubImage(image.Rect(0, y, 400, y+1)), current.SubImage(image.Rect(0, y, 400, y+1)), opts, nil)
+	}
+
+	Value(t, "mismatches", mismatches).ToBe(serial)
+
+	counted := 0
+
+	for _, c := range classes {
+		if c == pixelMismatch {
+			counted++
+		}
+	}
+
+	Value(t, "classified mismatches", counted).ToBe(mismatches)
+}
+
+// benchmarkImages returns a 4K screenshot sized snapshot and a copy with a changed area.
+func benchmarkImages() (*image.NRGBA, *image.NRGBA) {
+	snapshot := noiseImage(image.Point{}, image.Pt(3840, 2160), 1)
+	current := image.NewNRGBA(snapshot.Bounds())
+	copy(current.Pix, snapshot.Pix)
+	draw.Draw(current, image.Rect(100, 100, 200, 200), image.Black, image.Point{}, draw.Src)
+
+	return snapshot, current
+}
+
+func BenchmarkIsSameImage(b *testing.B) {
+	snapshot, current := benchmarkImages()
+	opts := newSnapshotOptions(nil)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		isSameImage(snapshot, current, opts)
+	}
+}
+
+func BenchmarkIsSameImageGeneric(b *testing.B) {
+	snapshot, current := benchmarkImages()
+	opts := newSnapshotOptions(nil)
+
+	// hiding the concrete type forces the At(x, y) path
+	type generic struct{ image.Image }
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		isSameImage(generic{snapshot}, generic{current}, opts)
+	}
+}
+
+func BenchmarkIsSameImageMismatch(b *testing.B) {
+	snapshot, current := benchmarkImages()
+	draw.Draw(current, image.Rect(0, 0, 3840, 1000), image.Black, image.Point{}, draw.Src)
+
+	opts := newSnapshotOptions(nil)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		isSameImage(snapshot, current, opts)
+	}
+}
+
+func BenchmarkIsSameImagePerceptual(b *testing.B) {
+	snapshot, current := benchmarkImages()
+	opts := newSnapshotOptions([]Option{WithPerceptualDiff()})
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		isSameImage(snapshot, current, opts)
+	}
+}
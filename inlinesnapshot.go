@@ -0,0 +1,159 @@
+package expect
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/akabio/expect/internal/textdiff"
+)
+
+// inlineEdit records a rewrite of an inline snapshot which changed the number of lines.
+type inlineEdit struct {
+	line  int
+	delta int
+}
+
+var (
+	inlineEditsLock sync.Mutex
+	// inlineEdits per file, used to map the line numbers of the compiled
+	// source to the lines of the rewritten file
+	inlineEdits = map[string][]inlineEdit{}
+)
+
+// ToMatchInlineSnapshot compares the value with the snapshot passed as argument. The value is
+// serialized like in ToBeSnapshot. When called without argument or in update mode the argument
+// is written into the calling source file.
+func (e Val) ToMatchInlineSnapshot(expected ...string) Val {
+	e.t.Helper()
+
+	if e.negate {
+		e.t.Fatalf("ToMatchInlineSnapshot can not be negated")
+	}
+
+	if len(expected) > 1 {
+		e.t.Fatalf("ToMatchInlineSnapshot takes at most one snapshot argument")
+	}
+
+	optOb := newSnapshotOptions(nil)
+	optOb.format = e.snapshotFormat("", optOb)
+
+	current, err := asBytes(e.value, optOb)
+	if err != nil {
+		e.t.Error(err)
+		return e
+	}
+
+	if len(expected) == 1 && expected[0] == string(current) {
+		return e
+	}
+
+	if len(expected) == 0 || e.ex.updateSnapshots() {
+		_, file, line, ok := runtime.Caller(1)
+		if !ok {
+			e.t.Fatalf("failed to locate the caller of ToMatchInlineSnapshot")
+		}
+
+		err = rewriteInlineSnapshot(file, line, string(current))
+		if err != nil {
+			e.t.Fatalf("failed to write inline snapshot to %v:%v, %v", file, line, err)
+		}
+
+		e.logf("updated inline snapshot at %v:%v", file, line)
+
+		return e
+	}
+
+	e.t.Errorf("expected %v to match inline snapshot\n%v", e.name,
+		textdiff.Unified(expected[0], string(current), "inline snapshot", "current", optOb.diffContext, optOb.diffMaxLines))
+
+	return e
+}
+
+// rewriteInlineSnapshot replaces the arguments of the ToMatchInlineSnapshot call at
+// the given line with the snapshot.
+func rewriteInlineSnapshot(file string, line int, snapshot string) error {
+	inlineEditsLock.Lock()
+	defer inlineEditsLock.Unlock()
+
+	// the line is from the compiled source, move it by the lines
+	// added or removed by previous rewrites
+	original := line
+	for _, ed := range inlineEdits[file] {
+		if ed.line < original {
+			line += ed.delta
+		}
+	}
+
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+
+	f, err := parser.ParseFile(fset, file, src, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	var call *ast.CallExpr
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		if call != nil {
+			return false
+		}
+
+		c, is := n.(*ast.CallExpr)
+		if !is {
+			return true
+		}
+
+		sel, is := c.Fun.(*ast.SelectorExpr)
+		if !is || sel.Sel.Name != "ToMatchInlineSnapshot" {
+			return true
+		}
+
+		if fset.Position(sel.Sel.Pos()).Line <= line && fset.Position(c.Rparen).Line >= line {
+			call = c
+		}
+
+		return true
+	})
+
+	if call == nil {
+		return fmt.Errorf("no call to ToMatchInlineSnapshot found at line %v", line)
+	}
+
+	start := fset.Position(call.Lparen).Offset + 1
+	end := fset.Position(call.Rparen).Offset
+
+	literal := inlineLiteral(snapshot)
+
+	out := make([]byte, 0, len(src)+len(literal))
+	out = append(out, src[:start]...)
+	out = append(out, literal...)
+	out = append(out, src[end:]...)
+
+	delta := strings.Count(literal, "\n") - strings.Count(string(src[start:end]), "\n")
+	if delta != 0 {
+		inlineEdits[file] = append(inlineEdits[file], inlineEdit{line: original, delta: delta})
+	}
+
+	return os.WriteFile(file, out, 0o644)
+}
+
+// inlineLiteral creates a go string literal, raw strings are preferred for readability.
+func inlineLiteral(s string) string {
+	if strings.ContainsAny(s, "`\r") {
+		return strconv.Quote(s)
+	}
+
+	return "`" + s + "`"
+}
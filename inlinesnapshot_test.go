@@ -0,0 +1,53 @@
+package expect_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/akabio/expect"
+)
+
+const inlineSource = `package x
+
+func TestX(t *testing.T) {
+	expect.Value(t, "a", "foo").ToMatchInlineSnapshot()
+	expect.Value(t, "b", "bar").
+		ToMatchInlineSnapshot("old")
+	expect.Value(t, "c", "baz").ToMatchInlineSnapshot()
+}
+`
+
+func TestRewriteInlineSnapshot(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "x_test.go")
+
+	err := os.WriteFile(file, []byte(inlineSource), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// lines are the ones of the original source, the rewrites must keep track of the added lines
+	expect.Error(t, expect.RewriteInlineSnapshot(file, 4, "foo\nfoo\n")).ToBe(nil)
+	expect.Error(t, expect.RewriteInlineSnapshot(file, 6, "bar")).ToBe(nil)
+	expect.Error(t, expect.RewriteInlineSnapshot(file, 7, "a`b")).ToBe(nil)
+
+	src, err := os.ReadFile(file)
+	expect.Error(t, err).ToBe(nil)
+	expect.Value(t, "source", string(src)).ToBe("package x\n\nfunc TestX(t *testing.T) {\n" +
+		"\texpect.Value(t, \"a\", \"foo\").ToMatchInlineSnapshot(`foo\nfoo\n`)\n" +
+		"\texpect.Value(t, \"b\", \"bar\").\n" +
+		"\t\tToMatchInlineSnapshot(`bar`)\n" +
+		"\texpect.Value(t, \"c\", \"baz\").ToMatchInlineSnapshot(\"a`b\")\n" +
+		"}\n")
+}
+
+func TestRewriteInlineSnapshotMissingCall(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "x_test.go")
+
+	err := os.WriteFile(file, []byte(inlineSource), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect.Error(t, expect.RewriteInlineSnapshot(file, 1, "foo")).Message().ToBe("no call to ToMatchInlineSnapshot found at line 1")
+}
@@ -0,0 +1,6 @@
+// Package imageext lists the file extensions of image snapshots, it's shared by the expect
+// package and the expect-snapshots command.
+package imageext
+
+// Extensions are the supported image snapshot formats.
+var Extensions = []string{".png", ".bmp", ".tif", ".tiff"}
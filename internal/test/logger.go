@@ -13,6 +13,7 @@ type Logger struct {
 	Fatals   []string
 	Errors   []string
 	Messages []string
+	Logs     []string
 	t        *testing.T
 }
 
@@ -55,6 +56,11 @@ func (l *Logger) Error(p ...interface{}) {
 	l.Messages = append(l.Messages, line)
 }
 
+// Logf records call.
+func (l *Logger) Logf(f string, i ...interface{}) {
+	l.Logs = append(l.Logs, fmt.Sprintf(f, i...))
+}
+
 func (l *Logger) Helper() {
 	// can be ignored, error logger does not care about location information
 }
@@ -0,0 +1,217 @@
+// Package textdiff creates line based diffs of texts and summaries of binary content.
+package textdiff
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// lineOp is a diff operation on whole lines.
+type lineOp struct {
+	kind  diffmatchpatch.Operation
+	lines []string
+}
+
+// diffLines diffs the texts line by line. Every distinct line is mapped to a single
+// rune so the character based diff of diffmatchpatch can be used.
+func diffLines(expected, actual string) []lineOp {
+	index := map[string]rune{}
+	lines := []string{}
+
+	toRunes := func(text string) []rune {
+		runes := []rune{}
+
+		for _, l := range splitLines(text) {
+			r, has := index[l]
+			if !has {
+				r = lineRune(len(lines))
+				index[l] = r
+				lines = append(lines, l)
+			}
+
+			runes = append(runes, r)
+		}
+
+		return runes
+	}
+
+	a := toRunes(expected)
+	b := toRunes(actual)
+
+	dmp := diffmatchpatch.New()
+	diffs := dmp.DiffMainRunes(a, b, false)
+
+	ops := make([]lineOp, 0, len(diffs))
+
+	for _, d := range diffs {
+		op := lineOp{kind: d.Type}
+		for _, r := range d.Text {
+			op.lines = append(op.lines, lines[runeLine(r)])
+		}
+
+		ops = append(ops, op)
+	}
+
+	return ops
+}
+
+// splitLines splits the text into lines without line endings.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// lineRune maps a line index to a valid unicode rune, skipping the surrogate range.
+func lineRune(i int) rune {
+	r := rune(i) + 0x100
+	if r >= 0xD800 {
+		r += 0x800
+	}
+
+	return r
+}
+
+func runeLine(r rune) int {
+	if r >= 0xD800+0x800 {
+		r -= 0x800
+	}
+
+	return int(r - 0x100)
+}
+
+// diffLine is a single line of a diff with its line numbers in both texts.
+type diffLine struct {
+	kind     diffmatchpatch.Operation
+	text     string
+	from, to int
+}
+
+// Unified creates a unified diff with the given number of context lines. The
+// output is limited to maxLines lines, 0 means unlimited.
+func Unified(from, to, fromName, toName string, context, maxLines int) string {
+	lines := []diffLine{}
+	fl, tl := 1, 1
+
+	for _, op := range diffLines(from, to) {
+		for _, l := range op.lines {
+			lines = append(lines, diffLine{kind: op.kind, text: l, from: fl, to: tl})
+
+			switch op.kind {
+			case diffmatchpatch.DiffEqual:
+				fl++
+				tl++
+			case diffmatchpatch.DiffDelete:
+				fl++
+			case diffmatchpatch.DiffInsert:
+				tl++
+			}
+		}
+	}
+
+	out := []string{"--- " + fromName, "+++ " + toName}
+
+	for i := 0; i < len(lines); {
+		if lines[i].kind == diffmatchpatch.DiffEqual {
+			i++
+			continue
+		}
+
+		// extend the hunk as long as the next change is within the context
+		start := max(0, i-context)
+		end := i
+
+		for j := i; j < len(lines) && j <= end+2*context; j++ {
+			if lines[j].kind != diffmatchpatch.DiffEqual {
+				end = j
+			}
+		}
+
+		end = min(len(lines)-1, end+context)
+		out = append(out, hunkHeader(lines[start:end+1]))
+
+		for _, l := range lines[start : end+1] {
+			switch l.kind {
+			case diffmatchpatch.DiffEqual:
+				out = append(out, " "+l.text)
+			case diffmatchpatch.DiffDelete:
+				out = append(out, "-"+l.text)
+			case diffmatchpatch.DiffInsert:
+				out = append(out, "+"+l.text)
+			}
+		}
+
+		i = end + 1
+	}
+
+	if len(out) == 2 {
+		out = append(out, "(only the line endings differ)")
+	}
+
+	if maxLines > 0 && len(out) > maxLines+2 {
+		more := len(out) - maxLines - 2
+		out = append(out[:maxLines+2], fmt.Sprintf("... %v more lines", more))
+	}
+
+	return strings.Join(out, "\n")
+}
+
+func hunkHeader(lines []diffLine) string {
+	fromStart, toStart := lines[0].from, lines[0].to
+	fromCount, toCount := 0, 0
+
+	for _, l := range lines {
+		if l.kind != diffmatchpatch.DiffInsert {
+			fromCount++
+		}
+
+		if l.kind != diffmatchpatch.DiffDelete {
+			toCount++
+		}
+	}
+
+	// empty ranges point to the line before the range
+	if fromCount == 0 {
+		fromStart--
+	}
+
+	if toCount == 0 {
+		toStart--
+	}
+
+	return fmt.Sprintf("@@ -%v,%v +%v,%v @@", fromStart, fromCount, toStart, toCount)
+}
+
+// IsBinary returns true if the data is not printable as text.
+func IsBinary(data []byte) bool {
+	return bytes.IndexByte(data, 0) >= 0 || !utf8.Valid(data)
+}
+
+// Binary summarizes the difference of two binary contents with a hex dump of
+// the first 16 bytes starting at the line of the first difference.
+func Binary(from, to []byte, fromName, toName string) string {
+	offset := 0
+	for offset < len(from) && offset < len(to) && from[offset] == to[offset] {
+		offset++
+	}
+
+	start := offset - offset%16
+
+	dump := func(data []byte) string {
+		if start >= len(data) {
+			return ""
+		}
+
+		return hex.EncodeToString(data[start:min(len(data), start+16)])
+	}
+
+	return fmt.Sprintf("binary content differs at offset %#x\n%v: %v bytes\n%v: %v bytes\n%08x %v: %v\n%08x %v: %v",
+		offset, fromName, len(from), toName, len(to), start, fromName, dump(from), start, toName, dump(to))
+}
@@ -0,0 +1,123 @@
+package expect
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+)
+
+// ToMatch asserts that the string value matches the regular expression. The pattern is not
+// anchored, use ^ and $ to match the whole string.
+func (e Val) ToMatch(pattern string) Val {
+	e.t.Helper()
+	e.check(e.toMatch(pattern))
+
+	return e
+}
+
+func (e Val) toMatch(pattern string) result {
+	e.t.Helper()
+
+	actual, is := e.value.(string)
+	if !is {
+		e.t.Fatalf("ToMatch must only be called on a string value")
+		return result{}
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		e.t.Fatalf("ToMatch pattern '%v' is invalid, %v", pattern, err)
+		return result{}
+	}
+
+	return result{
+		pass: re.MatchString(actual),
+		message: func(negated bool) string {
+			return fmt.Sprintf("expected %v%v to match '%v' but it is '%v'", e.name, not(negated), pattern, actual)
+		},
+	}
+}
+
+// ToMatchGlob asserts that the string value matches the pattern with the wildcards of path.Match.
+// The pattern must match the whole string, * does not match /.
+func (e Val) ToMatchGlob(pattern string) Val {
+	e.t.Helper()
+	e.check(e.toMatchGlob(pattern))
+
+	return e
+}
+
+func (e Val) toMatchGlob(pattern string) result {
+	e.t.Helper()
+
+	actual, is := e.value.(string)
+	if !is {
+		e.t.Fatalf("ToMatchGlob must only be called on a string value")
+		return result{}
+	}
+
+	matched, err := path.Match(pattern, actual)
+	if err != nil {
+		e.t.Fatalf("ToMatchGlob pattern '%v' is invalid, %v", pattern, err)
+		return result{}
+	}
+
+	return result{
+		pass: matched,
+		message: func(negated bool) string {
+			return fmt.Sprintf("expected %v%v to match glob '%v' but it is '%v'", e.name, not(negated), pattern, actual)
+		},
+	}
+}
+
+// Match is the result of matching a string value with a regular expression.
+type Match struct {
+	val     Val
+	pattern string
+	groups  []string
+}
+
+// Matching matches the string value with the regular expression, the capture groups of the first
+// match can be accessed with Group.
+func (e Val) Matching(pattern string) Match {
+	e.t.Helper()
+
+	actual, is := e.value.(string)
+	if !is {
+		e.t.Fatalf("Matching must only be called on a string value")
+		return Match{val: e, pattern: pattern}
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		e.t.Fatalf("Matching pattern '%v' is invalid, %v", pattern, err)
+		return Match{val: e, pattern: pattern}
+	}
+
+	return Match{val: e, pattern: pattern, groups: re.FindStringSubmatch(actual)}
+}
+
+// Group returns the n-th capture group as new value, group 0 is the whole match. It fails
+// fatally if the value does not match.
+func (m Match) Group(n int) Val {
+	e := m.val
+	e.t.Helper()
+
+	if m.groups == nil {
+		e.t.Fatalf("expected %v to match '%v' but it is '%v'", e.name, m.pattern, e.value)
+		return e
+	}
+
+	if n < 0 || n >= len(m.groups) {
+		e.t.Fatalf("'%v' has %v groups, group %v is out of bounds", m.pattern, len(m.groups)-1, n)
+		return e
+	}
+
+	return Val{
+		ex:    e.ex,
+		name:  "group " + strconv.Itoa(n) + " of " + e.name,
+		t:     e.t,
+		value: m.groups[n],
+	}
+}
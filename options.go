@@ -0,0 +1,290 @@
+package expect
+
+import (
+	"image"
+	"image/png"
+	"regexp"
+)
+
+// Option configures snapshot expectations.
+type Option interface {
+	apply(o *snapshotOptions)
+}
+
+type snapshotOptions struct {
+	pixelTolerance float64
+	matchTolerance float64
+	perceptual     bool
+	minSSIM        float64
+	ignoreRegions  []image.Rectangle
+	masks          []image.Image
+	compareImage   bool
+	sizeTolerance  int
+	crop           bool
+	pngCompression png.CompressionLevel
+	diffContext    int
+	diffMaxLines   int
+	redactions     []redaction
+	redactFields   []string
+	format         SnapshotFormat
+	semantic       *bool
+}
+
+// redaction replaces all matches of pattern in the serialized snapshot.
+type redaction struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+func newSnapshotOptions(opts []Option) *snapshotOptions {
+	o := &snapshotOptions{
+		pixelTolerance: 0.1,
+		matchTolerance: 0.01,
+		diffContext:    3,
+		diffMaxLines:   50,
+	}
+
+	for _, opt := range opts {
+		opt.apply(o)
+	}
+
+	return o
+}
+
+type options struct {
+	pixelTolerance *float64
+	matchTolerance *float64
+	perceptual     *bool
+	minSSIM        *float64
+	ignoreRegions  []image.Rectangle
+	masks          []image.Image
+	compareImage   *bool
+	sizeTolerance  *int
+	crop           *bool
+	pngCompression *png.CompressionLevel
+	diffContext    *int
+	diffMaxLines   *int
+	redactions     []redaction
+	redactFields   []string
+	format         SnapshotFormat
+	semantic       *bool
+}
+
+func WithExact() Option {
+	return &options{
+		pixelTolerance: fptr(0),
+		matchTolerance: fptr(0),
+	}
+}
+
+// WithPixelTolerance sets the maximum allowed color difference for a pixel to be considered a match.
+// 0 means exact match only. 1 means all pixels matched.
+func WithPixelTolerance(t float64) Option {
+	return &options{
+		pixelTolerance: fptr(t),
+	}
+}
+
+// WithMatchTolerance sets the maximum fraction of pixels that may differ while still accepting the image.
+// 0 means no mismatches allowed. 1 means all mismatches allowed.
+func WithMatchTolerance(t float64) Option {
+	return &options{
+		matchTolerance: fptr(t),
+	}
+}
+
+// WithPerceptualDiff compares the pixels by their perceived color difference in the YIQ color
+// space and ignores pixels which are part of anti-aliased edges. The pixel tolerance is the
+// threshold of the perceived difference, the default of 0.1 works well for most images.
+// Anti-aliased pixels are marked yellow, other mismatches red in the diff image.
+func WithPerceptualDiff() Option {
+	return &options{
+		perceptual: bptr(true),
+	}
+}
+
+// WithMinSSIM accepts the image if the structural similarity index (SSIM) of the brightness of
+// the snapshot and the image is at least min. The SSIM is 1 for identical images. When set
+// the match tolerance is not used.
+func WithMinSSIM(min float64) Option {
+	return &options{
+		minSSIM: fptr(min),
+	}
+}
+
+// WithIgnoreRegion excludes the pixels within the rectangle from the image comparison. The
+// rectangle is relative to the top left corner of the image. Ignored pixels are hatched in the
+// diff image.
+func WithIgnoreRegion(r image.Rectangle) Option {
+	return &options{
+		ignoreRegions: []image.Rectangle{r},
+	}
+}
+
+// WithMask excludes all pixels from the image comparison where the mask is not fully transparent.
+// The mask is aligned with the top left corner of the image. Ignored pixels are hatched in the
+// diff image.
+func WithMask(mask image.Image) Option {
+	return &options{
+		masks: []image.Image{mask},
+	}
+}
+
+// WithCompareImage writes a .compare.png file for mismatching image snapshots which shows the
+// snapshot, the current image and the diff side by side.
+func WithCompareImage() Option {
+	return &options{
+		compareImage: bptr(true),
+	}
+}
+
+// WithSizeTolerance accepts images whose width and height differ by at most px pixels from the
+// snapshot. Only the overlapping area at the top left is compared.
+func WithSizeTolerance(px int) Option {
+	return &options{
+		sizeTolerance: iptr(px),
+	}
+}
+
+// WithCrop compares only the overlapping area at the top left when the image size differs
+// from the snapshot.
+func WithCrop() Option {
+	return &options{
+		crop: bptr(true),
+	}
+}
+
+// WithPNGCompression sets the compression level used to write .png image snapshots.
+func WithPNGCompression(level png.CompressionLevel) Option {
+	return &options{
+		pngCompression: &level,
+	}
+}
+
+// WithDiffContext sets the number of unchanged lines shown around each change in the diff
+// of a mismatching text snapshot. Defaults to 3.
+func WithDiffContext(lines int) Option {
+	return &options{
+		diffContext: iptr(lines),
+	}
+}
+
+// WithDiffMaxLines limits the number of lines of the diff of a mismatching text snapshot.
+// Defaults to 50, 0 means no limit.
+func WithDiffMaxLines(lines int) Option {
+	return &options{
+		diffMaxLines: iptr(lines),
+	}
+}
+
+// WithRedact replaces all matches of the pattern in the serialized snapshot value with replacement
+// before it is compared and written. The replacement can reference groups like regexp.ReplaceAllString.
+func WithRedact(pattern *regexp.Regexp, replacement string) Option {
+	return &options{
+		redactions: []redaction{{pattern: pattern, replacement: replacement}},
+	}
+}
+
+// WithRedactField replaces the values of all struct fields or map entries with the given name by
+// <redacted> before the snapshot value is serialized. The name is matched case insensitive against
+// the json name of the field. The value is redacted in its json representation, so it can't be used
+// with GoFormat and SpewFormat.
+func WithRedactField(name string) Option {
+	return &options{
+		redactFields: []string{name},
+	}
+}
+
+// WithRedactTimestamps replaces all RFC3339 timestamps in the snapshot by <timestamp>.
+func WithRedactTimestamps() Option {
+	return WithRedact(timestampPattern, "<timestamp>")
+}
+
+// WithRedactUUIDs replaces all UUIDs in the snapshot by <uuid>.
+func WithRedactUUIDs() Option {
+	return WithRedact(uuidPattern, "<uuid>")
+}
+
+// WithFormat sets the format used to serialize snapshot values which are not a string or []byte.
+// Without this option the format is inferred from the extension of the snapshot path.
+func WithFormat(f SnapshotFormat) Option {
+	return &options{
+		format: f,
+	}
+}
+
+// WithSemanticCompare enables or disables the structural comparison of snapshots. When enabled
+// the snapshot and the current output are parsed as JSON or YAML and compared by value, so formatting
+// and key order do not matter. Enabled by default for .json, .yaml and .yml snapshots.
+func WithSemanticCompare(enabled bool) Option {
+	return &options{
+		semantic: bptr(enabled),
+	}
+}
+
+func (s *options) apply(o *snapshotOptions) {
+	if s.pixelTolerance != nil {
+		o.pixelTolerance = *s.pixelTolerance
+	}
+
+	if s.matchTolerance != nil {
+		o.matchTolerance = *s.matchTolerance
+	}
+
+	if s.perceptual != nil {
+		o.perceptual = *s.perceptual
+	}
+
+	if s.minSSIM != nil {
+		o.minSSIM = *s.minSSIM
+	}
+
+	if s.compareImage != nil {
+		o.compareImage = *s.compareImage
+	}
+
+	if s.sizeTolerance != nil {
+		o.sizeTolerance = *s.sizeTolerance
+	}
+
+	if s.crop != nil {
+		o.crop = *s.crop
+	}
+
+	if s.pngCompression != nil {
+		o.pngCompression = *s.pngCompression
+	}
+
+	if s.diffContext != nil {
+		o.diffContext = *s.diffContext
+	}
+
+	if s.diffMaxLines != nil {
+		o.diffMaxLines = *s.diffMaxLines
+	}
+
+	if s.format != nil {
+		o.format = s.format
+	}
+
+	if s.semantic != nil {
+		o.semantic = s.semantic
+	}
+
+	o.ignoreRegions = append(o.ignoreRegions, s.ignoreRegions...)
+	o.masks = append(o.masks, s.masks...)
+	o.redactions = append(o.redactions, s.redactions...)
+	o.redactFields = append(o.redactFields, s.redactFields...)
+}
+
+func fptr(f float64) *float64 {
+	return &f
+}
+
+func iptr(i int) *int {
+	return &i
+}
+
+func bptr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,274 @@
+package expect
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// ToBeGreaterThan asserts that the value is greater than x. Works for numbers, strings, time.Time,
+// time.Duration and types with a Compare or Before method. NaN fails fatally, it can not be ordered.
+func (e Val) ToBeGreaterThan(x interface{}) Val {
+	e.t.Helper()
+	e.check(e.toBeGreaterThan(x))
+
+	return e
+}
+
+func (e Val) toBeGreaterThan(x interface{}) result {
+	e.t.Helper()
+	return e.toBeOrdered("ToBeGreaterThan", "greater than", x, func(c int) bool { return c > 0 })
+}
+
+// ToBeGreaterOrEqual asserts that the value is greater than or equal to x.
+func (e Val) ToBeGreaterOrEqual(x interface{}) Val {
+	e.t.Helper()
+	e.check(e.toBeGreaterOrEqual(x))
+
+	return e
+}
+
+func (e Val) toBeGreaterOrEqual(x interface{}) result {
+	e.t.Helper()
+	return e.toBeOrdered("ToBeGreaterOrEqual", "greater than or equal to", x, func(c int) bool { return c >= 0 })
+}
+
+// ToBeLessThan asserts that the value is less than x.
+func (e Val) ToBeLessThan(x interface{}) Val {
+	e.t.Helper()
+	e.check(e.toBeLessThan(x))
+
+	return e
+}
+
+func (e Val) toBeLessThan(x interface{}) result {
+	e.t.Helper()
+	return e.toBeOrdered("ToBeLessThan", "less than", x, func(c int) bool { return c < 0 })
+}
+
+// ToBeLessOrEqual asserts that the value is less than or equal to x.
+func (e Val) ToBeLessOrEqual(x interface{}) Val {
+	e.t.Helper()
+	e.check(e.toBeLessOrEqual(x))
+
+	return e
+}
+
+func (e Val) toBeLessOrEqual(x interface{}) result {
+	e.t.Helper()
+	return e.toBeOrdered("ToBeLessOrEqual", "less than or equal to", x, func(c int) bool { return c <= 0 })
+}
+
+// ToBeBetween asserts that the value is between lo and hi, both bounds are included.
+func (e Val) ToBeBetween(lo, hi interface{}) Val {
+	e.t.Helper()
+	e.check(e.toBeBetween(lo, hi))
+
+	return e
+}
+
+func (e Val) toBeBetween(lo, hi interface{}) result {
+	e.t.Helper()
+
+	if hasNaN(e.value, lo, hi) {
+		e.t.Fatalf("ToBeBetween() can not order NaN, %v is %v with bounds %v and %v", e.name,
+			formatValue(reflect.ValueOf(e.value)), formatValue(reflect.ValueOf(lo)), formatValue(reflect.ValueOf(hi)))
+		return result{}
+	}
+
+	cl, okl := compareOrdered(e.value, lo)
+	ch, okh := compareOrdered(e.value, hi)
+
+	if !okl || !okh {
+		e.t.Fatalf("ToBeBetween() can not compare %T with %T and %T", e.value, lo, hi)
+		return result{}
+	}
+
+	return result{
+		pass: cl >= 0 && ch <= 0,
+		message: func(negated bool) string {
+			return fmt.Sprintf("expected %v%v to be between %v and %v but it is %v", e.name, not(negated),
+				formatValue(reflect.ValueOf(lo)), formatValue(reflect.ValueOf(hi)), formatValue(reflect.ValueOf(e.value)))
+		},
+	}
+}
+
+func (e Val) toBeOrdered(matcher, relation string, x interface{}, pass func(c int) bool) result {
+	e.t.Helper()
+
+	if hasNaN(e.value, x) {
+		e.t.Fatalf("%v() can not order NaN, %v is %v compared to %v", matcher, e.name,
+			formatValue(reflect.ValueOf(e.value)), formatValue(reflect.ValueOf(x)))
+		return result{}
+	}
+
+	c, ok := compareOrdered(e.value, x)
+	if !ok {
+		e.t.Fatalf("%v() can not compare %T with %T", matcher, e.value, x)
+		return result{}
+	}
+
+	return result{
+		pass: pass(c),
+		message: func(negated bool) string {
+			return fmt.Sprintf("expected %v%v to be %v %v but it is %v", e.name, not(negated), relation,
+				formatValue(reflect.ValueOf(x)), formatValue(reflect.ValueOf(e.value)))
+		},
+	}
+}
+
+// compareOrdered returns -1, 0 or 1 if a is less than, equal to or greater than b. The second
+// result is false if the values can not be compared. Numbers of different kinds are compared
+// by their value.
+func compareOrdered(a, b interface{}) (int, bool) {
+	if a == nil || b == nil {
+		return 0, false
+	}
+
+	if ta, is := a.(time.Time); is {
+		tb, is := b.(time.Time)
+		if !is {
+			return 0, false
+		}
+
+		switch {
+		case ta.Before(tb):
+			return -1, true
+		case ta.After(tb):
+			return 1, true
+		}
+
+		return 0, true
+	}
+
+	va := reflect.ValueOf(a)
+	vb := reflect.ValueOf(b)
+
+	switch {
+	case isNumber(va) && isNumber(vb):
+		// named types like time.Duration have a unit, they are only compared with the same type
+		if (isNamed(va.Type()) || isNamed(vb.Type())) && va.Type() != vb.Type() {
+			return 0, false
+		}
+
+		return compareNumbers(va, vb), true
+
+	case va.Kind() == reflect.String && vb.Kind() == reflect.String:
+		return compareValues(va.String(), vb.String()), true
+	}
+
+	if va.Type() != vb.Type() {
+		return 0, false
+	}
+
+	if m := va.MethodByName("Compare"); m.IsValid() && m.Type().NumIn() == 1 && m.Type().In(0) == vb.Type() &&
+		m.Type().NumOut() == 1 && m.Type().Out(0).Kind() == reflect.Int {
+		return compareValues(int(m.Call([]reflect.Value{vb})[0].Int()), 0), true
+	}
+
+	if m := va.MethodByName("Before"); m.IsValid() && m.Type().NumIn() == 1 && m.Type().In(0) == vb.Type() &&
+		m.Type().NumOut() == 1 && m.Type().Out(0).Kind() == reflect.Bool {
+		switch {
+		case m.Call([]reflect.Value{vb})[0].Bool():
+			return -1, true
+		case vb.MethodByName("Before").Call([]reflect.Value{va})[0].Bool():
+			return 1, true
+		}
+
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// hasNaN returns true if one of the values is a float NaN, it is neither less than, equal to
+// nor greater than any number.
+func hasNaN(values ...interface{}) bool {
+	for _, v := range values {
+		rv := reflect.ValueOf(v)
+		if isFloat(rv) && math.IsNaN(rv.Float()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isNamed returns true for declared types like time.Duration, false for the predeclared types.
+func isNamed(t reflect.Type) bool {
+	return t.PkgPath() != ""
+}
+
+func isNumber(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+// compareNumbers compares numbers of any kind without losing precision of large integers.
+func compareNumbers(a, b reflect.Value) int {
+	switch {
+	case isFloat(a) || isFloat(b):
+		return compareValues(toFloat(a), toFloat(b))
+
+	case isUnsigned(a) && isUnsigned(b):
+		return compareValues(a.Uint(), b.Uint())
+
+	case isUnsigned(a):
+		if b.Int() < 0 {
+			return 1
+		}
+
+		return compareValues(a.Uint(), uint64(b.Int()))
+
+	case isUnsigned(b):
+		if a.Int() < 0 {
+			return -1
+		}
+
+		return compareValues(uint64(a.Int()), b.Uint())
+	}
+
+	return compareValues(a.Int(), b.Int())
+}
+
+func isFloat(v reflect.Value) bool {
+	return v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64
+}
+
+func isUnsigned(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+
+	return false
+}
+
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case isFloat(v):
+		return v.Float()
+	case isUnsigned(v):
+		return float64(v.Uint())
+	}
+
+	return float64(v.Int())
+}
+
+func compareValues[T int | int64 | uint64 | float64 | string](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
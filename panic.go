@@ -0,0 +1,177 @@
+package expect
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+)
+
+// FuncVal wraps a function and provides expectations for its panics.
+type FuncVal struct {
+	ex   *Expect
+	name string
+	t    Test
+	f    func() error
+}
+
+// outcome is the result of calling the function of a FuncVal.
+type outcome struct {
+	panicked  bool
+	recovered interface{}
+	stack     []byte
+	err       error
+}
+
+// Func wraps a func() or func() error and provides expectations for its panics.
+// It delegates to the default instance `Default`.
+func Func(t Test, name string, f interface{}) FuncVal {
+	return Default.Func(t, name, f)
+}
+
+// Func wraps a func() or func() error and provides expectations for its panics.
+func (e *Expect) Func(t Test, name string, f interface{}) FuncVal {
+	t.Helper()
+
+	fv := FuncVal{ex: e, name: name, t: t}
+
+	switch fn := f.(type) {
+	case func():
+		fv.f = func() error {
+			fn()
+			return nil
+		}
+	case func() error:
+		fv.f = fn
+	default:
+		t.Fatalf("Func must be called with a func() or func() error but it is %T", f)
+	}
+
+	return fv
+}
+
+// call runs the function and recovers a panic.
+func (e FuncVal) call() (o outcome) {
+	done := false
+
+	defer func() {
+		if !done {
+			o.panicked = true
+			o.recovered = recover()
+			o.stack = debug.Stack()
+		}
+	}()
+
+	o.err = e.f()
+	done = true
+
+	return o
+}
+
+// panicVal returns the recovered value as new value.
+func (e FuncVal) panicVal(o outcome) Val {
+	return Val{
+		ex:    e.ex,
+		name:  "panic of " + e.name,
+		t:     e.t,
+		value: o.recovered,
+	}
+}
+
+// ToPanic asserts that the function panics. The returned value is the recovered value.
+func (e FuncVal) ToPanic() Val {
+	e.t.Helper()
+
+	o := e.call()
+	if !o.panicked {
+		e.t.Errorf("expected %v to panic but it did not%v", e.name, returned(o.err))
+	}
+
+	return e.panicVal(o)
+}
+
+// ToPanicWith asserts that the function panics with a value deeply equal to expected.
+// The returned value is the recovered value.
+func (e FuncVal) ToPanicWith(expected interface{}) Val {
+	e.t.Helper()
+
+	o := e.call()
+	if !o.panicked {
+		e.t.Errorf("expected %v to panic with %v but it did not panic%v", e.name, formatPanic(expected), returned(o.err))
+		return e.panicVal(o)
+	}
+
+	return e.panicVal(o).ToBe(expected)
+}
+
+// ToPanicMatching asserts that the function panics with a value whose message matches the
+// regular expression. Errors are matched by their Error() message, other values as formatted by fmt.
+// The returned value is the recovered value.
+func (e FuncVal) ToPanicMatching(pattern string) Val {
+	e.t.Helper()
+
+	o := e.call()
+	if !o.panicked {
+		e.t.Errorf("expected %v to panic matching '%v' but it did not panic%v", e.name, pattern, returned(o.err))
+		return e.panicVal(o)
+	}
+
+	msg := Val{
+		ex:    e.ex,
+		name:  "panic of " + e.name,
+		t:     e.t,
+		value: panicMessage(o.recovered),
+	}
+	msg.check(msg.toMatch(pattern))
+
+	return e.panicVal(o)
+}
+
+// NotToPanic asserts that the function does not panic, an unexpected panic is reported with its
+// stack trace. The returned value is the error returned by a func() error.
+func (e FuncVal) NotToPanic() Val {
+	e.t.Helper()
+
+	o := e.call()
+	if o.panicked {
+		e.t.Errorf("expected %v NOT to panic but it panicked with %v\n%v", e.name, formatPanic(o.recovered), indent(strings.TrimSpace(string(o.stack)), block))
+	}
+
+	var err interface{}
+	if o.err != nil {
+		err = o.err
+	}
+
+	return Val{
+		ex:    e.ex,
+		name:  e.name + " error",
+		t:     e.t,
+		value: err,
+	}
+}
+
+// panicMessage returns the message of a recovered value.
+func panicMessage(r interface{}) string {
+	if err, is := r.(error); is {
+		return err.Error()
+	}
+
+	return fmt.Sprint(r)
+}
+
+// formatPanic formats a recovered value with its type.
+func formatPanic(r interface{}) string {
+	if r == nil {
+		return "nil"
+	}
+
+	return fmt.Sprintf("%T '%v'", r, panicMessage(r))
+}
+
+// returned describes the error returned by the function which did not panic.
+func returned(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return ", it returned " + formatError(err)
+}
@@ -0,0 +1,290 @@
+package expect
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Poll retries expectations on a value which changes over time. It's created by Eventually
+// and Consistently.
+type Poll struct {
+	ex           *Expect
+	name         string
+	t            Test
+	f            func() interface{}
+	timeout      time.Duration
+	interval     time.Duration
+	consistently bool
+	negate       bool
+}
+
+// Eventually calls f every interval until the expectation is met or the timeout is reached.
+// It delegates to the default instance `Default`.
+func Eventually(t Test, name string, f func() interface{}, timeout, interval time.Duration) Poll {
+	return Default.Eventually(t, name, f, timeout, interval)
+}
+
+// Consistently calls f every interval until the timeout is reached, the expectation must be
+// met every time. It delegates to the default instance `Default`.
+func Consistently(t Test, name string, f func() interface{}, timeout, interval time.Duration) Poll {
+	return Default.Consistently(t, name, f, timeout, interval)
+}
+
+// Eventually calls f every interval until the expectation is met or the timeout is reached.
+func (e *Expect) Eventually(t Test, name string, f func() interface{}, timeout, interval time.Duration) Poll {
+	return Poll{ex: e, name: name, t: t, f: f, timeout: timeout, interval: interval}
+}
+
+// Consistently calls f every interval until the timeout is reached, the expectation must be
+// met every time.
+func (e *Expect) Consistently(t Test, name string, f func() interface{}, timeout, interval time.Duration) Poll {
+	return Poll{ex: e, name: name, t: t, f: f, timeout: timeout, interval: interval, consistently: true}
+}
+
+// Not returns a negated poll, all expectations called on it must not be met.
+func (p Poll) Not() Poll {
+	p.negate = !p.negate
+	return p
+}
+
+// attempt is the Test of a single poll attempt. It records the failures instead of reporting
+// them, a fatal failure, like a value of the wrong type, ends the attempt. The failures are only
+// reported if no later attempt succeeds.
+type attempt struct {
+	Test
+	failures []string
+}
+
+// fatalAttempt is the panic value which ends an attempt.
+type fatalAttempt struct{}
+
+func (a *attempt) Fatalf(f string, i ...interface{}) {
+	a.failures = append(a.failures, fmt.Sprintf(f, i...))
+	panic(fatalAttempt{})
+}
+
+func (a *attempt) Errorf(f string, i ...interface{}) {
+	a.failures = append(a.failures, fmt.Sprintf(f, i...))
+}
+
+func (a *attempt) Error(p ...interface{}) {
+	a.failures = append(a.failures, fmt.Sprint(p...))
+}
+
+// Logf passes logs, like the ones of updated snapshots, to the test.
+func (a *attempt) Logf(f string, i ...interface{}) {
+	if l, is := a.Test.(interface {
+		Logf(f string, i ...interface{})
+	}); is {
+		l.Logf(f, i...)
+	}
+}
+
+// try evaluates the expectation on v and returns the failures, the expectation is met if
+// there are none.
+func (p Poll) try(expectation func(v Val) Val, v Val) (failures []string) {
+	a := &attempt{Test: p.t}
+	v.t = a
+
+	defer func() {
+		if r := recover(); r != nil {
+			if _, is := r.(fatalAttempt); !is {
+				panic(r)
+			}
+		}
+
+		failures = a.failures
+	}()
+
+	expectation(v)
+
+	return a.failures
+}
+
+// To polls any expectation on Val, like ToBeErrorAs or ToMatchSnapshotImage. The expectation
+// is called with the value of every attempt.
+func (p Poll) To(expectation func(v Val) Val) Poll {
+	p.t.Helper()
+	return p.poll(expectation)
+}
+
+// poll evaluates the expectation on the values of f and reports the failures of the last attempt.
+func (p Poll) poll(expectation func(v Val) Val) Poll {
+	p.t.Helper()
+
+	deadline := time.Now().Add(p.timeout)
+
+	for attempt := 1; ; attempt++ {
+		v := Val{ex: p.ex, name: p.name, t: p.t, value: p.f(), negate: p.negate}
+
+		failures := p.try(expectation, v)
+		met := len(failures) == 0
+
+		switch {
+		case p.consistently && !met:
+			p.t.Errorf("%v\nfailed on attempt %v of polling for %v, last value: %v",
+				strings.Join(failures, "\n"), attempt, p.timeout, formatValue(reflect.ValueOf(v.value)))
+
+			return p
+
+		case !p.consistently && met:
+			return p
+		}
+
+		if !time.Now().Before(deadline) {
+			if !p.consistently {
+				p.t.Errorf("%v\ngave up after %v attempts within %v, last value: %v",
+					strings.Join(failures, "\n"), attempt, p.timeout, formatValue(reflect.ValueOf(v.value)))
+			}
+
+			return p
+		}
+
+		wait := time.Until(deadline)
+		if p.interval < wait {
+			wait = p.interval
+		}
+
+		time.Sleep(wait)
+	}
+}
+
+// ToBe asserts that the value is deeply equals to expected value.
+func (p Poll) ToBe(expected interface{}) Poll {
+	p.t.Helper()
+	return p.poll(func(v Val) Val { return v.ToBe(expected) })
+}
+
+// NotToBe asserts that the value is not deeply equals to the unexpected value.
+func (p Poll) NotToBe(unExpected interface{}) Poll {
+	p.t.Helper()
+	return p.poll(func(v Val) Val { return v.NotToBe(unExpected) })
+}
+
+// ToCount asserts that the list/map/chan/string has c elements.
+func (p Poll) ToCount(c int) Poll {
+	p.t.Helper()
+	return p.poll(func(v Val) Val { return v.ToCount(c) })
+}
+
+// ToContain asserts that the slice contains the element or the string contains the substring.
+func (p Poll) ToContain(expected interface{}) Poll {
+	p.t.Helper()
+	return p.poll(func(v Val) Val { return v.ToContain(expected) })
+}
+
+// ToBeAbout asserts that the number is in deltas range of expected value.
+func (p Poll) ToBeAbout(expected, delta float64) Poll {
+	p.t.Helper()
+	return p.poll(func(v Val) Val { return v.ToBeAbout(expected, delta) })
+}
+
+// ToHavePrefix asserts that the string value starts with the provided prefix.
+func (p Poll) ToHavePrefix(prefix string) Poll {
+	p.t.Helper()
+	return p.poll(func(v Val) Val { return v.ToHavePrefix(prefix) })
+}
+
+// ToHaveSuffix asserts that the string value ends with the provided suffix.
+func (p Poll) ToHaveSuffix(suffix string) Poll {
+	p.t.Helper()
+	return p.poll(func(v Val) Val { return v.ToHaveSuffix(suffix) })
+}
+
+// ToBeType asserts that the value is of the same type as t.
+func (p Poll) ToBeType(t interface{}) Poll {
+	p.t.Helper()
+	return p.poll(func(v Val) Val { return v.ToBeType(t) })
+}
+
+// ToBeGreaterThan asserts that the value is greater than x.
+func (p Poll) ToBeGreaterThan(x interface{}) Poll {
+	p.t.Helper()
+	return p.poll(func(v Val) Val { return v.ToBeGreaterThan(x) })
+}
+
+// ToBeGreaterOrEqual asserts that the value is greater than or equal to x.
+func (p Poll) ToBeGreaterOrEqual(x interface{}) Poll {
+	p.t.Helper()
+	return p.poll(func(v Val) Val { return v.ToBeGreaterOrEqual(x) })
+}
+
+// ToBeLessThan asserts that the value is less than x.
+func (p Poll) ToBeLessThan(x interface{}) Poll {
+	p.t.Helper()
+	return p.poll(func(v Val) Val { return v.ToBeLessThan(x) })
+}
+
+// ToBeLessOrEqual asserts that the value is less than or equal to x.
+func (p Poll) ToBeLessOrEqual(x interface{}) Poll {
+	p.t.Helper()
+	return p.poll(func(v Val) Val { return v.ToBeLessOrEqual(x) })
+}
+
+// ToBeBetween asserts that the value is between lo and hi, both bounds are included.
+func (p Poll) ToBeBetween(lo, hi interface{}) Poll {
+	p.t.Helper()
+	return p.poll(func(v Val) Val { return v.ToBeBetween(lo, hi) })
+}
+
+// ToBeNil asserts that the value is nil.
+func (p Poll) ToBeNil() Poll {
+	p.t.Helper()
+	return p.poll(func(v Val) Val { return v.ToBeNil() })
+}
+
+// ToBeNotNil asserts that the value is not nil.
+func (p Poll) ToBeNotNil() Poll {
+	p.t.Helper()
+	return p.poll(func(v Val) Val { return v.ToBeNotNil() })
+}
+
+// ToBeEmpty asserts that the value has no elements or is the zero value.
+func (p Poll) ToBeEmpty() Poll {
+	p.t.Helper()
+	return p.poll(func(v Val) Val { return v.ToBeEmpty() })
+}
+
+// ToBeZero asserts that the value is the zero value of its type.
+func (p Poll) ToBeZero() Poll {
+	p.t.Helper()
+	return p.poll(func(v Val) Val { return v.ToBeZero() })
+}
+
+// ToBeTrue asserts that the bool value is true.
+func (p Poll) ToBeTrue() Poll {
+	p.t.Helper()
+	return p.poll(func(v Val) Val { return v.ToBeTrue() })
+}
+
+// ToBeFalse asserts that the bool value is false.
+func (p Poll) ToBeFalse() Poll {
+	p.t.Helper()
+	return p.poll(func(v Val) Val { return v.ToBeFalse() })
+}
+
+// ToSucceed asserts that the error is nil.
+func (p Poll) ToSucceed() Poll {
+	p.t.Helper()
+	return p.poll(func(v Val) Val { return v.ToSucceed() })
+}
+
+// ToWrap asserts that the error is or wraps the target error.
+func (p Poll) ToWrap(target error) Poll {
+	p.t.Helper()
+	return p.poll(func(v Val) Val { return v.ToWrap(target) })
+}
+
+// ToMatch asserts that the string value matches the regular expression.
+func (p Poll) ToMatch(pattern string) Poll {
+	p.t.Helper()
+	return p.poll(func(v Val) Val { return v.ToMatch(pattern) })
+}
+
+// ToMatchGlob asserts that the string value matches the pattern with the wildcards of path.Match.
+func (p Poll) ToMatchGlob(pattern string) Poll {
+	p.t.Helper()
+	return p.poll(func(v Val) Val { return v.ToMatchGlob(pattern) })
+}
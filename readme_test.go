@@ -16,12 +16,9 @@ func TestReadmeToBeString(t *testing.T) {
 func TestReadmeToToBeArray(t *testing.T) {
 	l := &test.Logger{}
 	expect.Value(l, "array", []int{3, 1}).ToBe([]int{1, 3})
-	expect.Value(t, "error", l.Messages[0]).ToBe(`expected array to be
-    - 1
-    - 3
-but it is
-    - 3
-    - 1`)
+	expect.Value(t, "error", l.Messages[0]).ToBe(`expected array to be equal but it has 2 differences
+    array[0]: expected 1 but it is 3
+    array[1]: expected 3 but it is 1`)
 }
 
 func TestReadmeToBeFloat64(t *testing.T) {
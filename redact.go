@@ -0,0 +1,73 @@
+package expect
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+var (
+	timestampPattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}[Tt ]\d{2}:\d{2}:\d{2}(\.\d+)?([Zz]|[+-]\d{2}:\d{2})`)
+	uuidPattern      = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+)
+
+// redact applies all redaction patterns to the serialized snapshot.
+func redact(data []byte, opts *snapshotOptions) []byte {
+	for _, r := range opts.redactions {
+		data = r.pattern.ReplaceAll(data, []byte(r.replacement))
+	}
+
+	return data
+}
+
+// redactFields converts the value into its generic json representation and replaces
+// the values of all fields with one of the given names. Numbers are kept as json.Number so
+// they are not rounded to float64.
+func redactFields(in any, fields []string) (any, error) {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic any
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	err = dec.Decode(&generic)
+	if err != nil {
+		return nil, err
+	}
+
+	return redactGeneric(generic, fields), nil
+}
+
+func redactGeneric(in any, fields []string) any {
+	switch t := in.(type) {
+	case map[string]any:
+		for k, v := range t {
+			if isRedactedField(k, fields) {
+				t[k] = "<redacted>"
+			} else {
+				t[k] = redactGeneric(v, fields)
+			}
+		}
+	case []any:
+		for i, v := range t {
+			t[i] = redactGeneric(v, fields)
+		}
+	}
+
+	return in
+}
+
+func isRedactedField(name string, fields []string) bool {
+	for _, f := range fields {
+		if strings.EqualFold(name, f) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,255 @@
+package expect
+
+import (
+	"bytes"
+	"encoding/base64"
+	"html/template"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// SnapshotReportEnv is the environment variable which sets the path of the HTML snapshot report.
+const SnapshotReportEnv = "EXPECT_SNAPSHOT_REPORT"
+
+// reportEntry is a single snapshot failure in the report.
+type reportEntry struct {
+	Test    string
+	Path    string
+	Message string
+	// Diff lines of text snapshots
+	Diff []reportLine
+	// images encoded as data URLs, empty for text snapshots
+	Snapshot template.URL
+	Current  template.URL
+	DiffImg  template.URL
+}
+
+// reportLine is a line of a text diff with its css class.
+type reportLine struct {
+	Class string
+	Text  string
+}
+
+// snapshotReports are the reports of this test binary by path, a report is removed when the
+// first snapshot is checked so it never shows failures of an earlier run.
+var (
+	snapshotReports   = map[string][]reportEntry{}
+	snapshotReportsMu sync.Mutex
+)
+
+// snapshotReport returns the path of the HTML report or "" if no report should be written.
+// It's set by the SnapshotReport field or the environment variable EXPECT_SNAPSHOT_REPORT.
+func (e *Expect) snapshotReport() string {
+	path := e.SnapshotReport
+	if path == "" {
+		path = os.Getenv(SnapshotReportEnv)
+	}
+
+	if path == "" {
+		return ""
+	}
+
+	return packageReport(path)
+}
+
+// packageReport adds the import path of the tested package to the report path. go test ./... runs
+// the packages in separate processes which must not overwrite each others report.
+func packageReport(path string) string {
+	ext := filepath.Ext(path)
+
+	return strings.TrimSuffix(path, ext) + "-" + testPackage() + ext
+}
+
+// testPackage returns the import path of the tested package usable as file name, like
+// github.com_akabio_expect. Without build info the name of the test binary is used.
+func testPackage() string {
+	if info, ok := debug.ReadBuildInfo(); ok && strings.HasSuffix(info.Path, ".test") {
+		return sanitizeFileName(strings.TrimSuffix(info.Path, ".test"))
+	}
+
+	pkg := strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe")
+
+	return sanitizeFileName(strings.TrimSuffix(pkg, ".test"))
+}
+
+// resetReport removes the report of an earlier run when the first snapshot of this test binary
+// is checked.
+func (e *Expect) resetReport() {
+	path := e.snapshotReport()
+	if path == "" {
+		return
+	}
+
+	snapshotReportsMu.Lock()
+	defer snapshotReportsMu.Unlock()
+
+	if _, seen := snapshotReports[path]; seen {
+		return
+	}
+
+	snapshotReports[path] = nil
+
+	_ = os.Remove(path)
+}
+
+// reportText adds a failed text snapshot to the report, the first line of the message is the
+// summary, the rest is shown as diff.
+func (e Val) reportText(path, msg string) {
+	lines := strings.Split(msg, "\n")
+	entry := reportEntry{Path: path, Message: lines[0]}
+
+	for _, l := range lines[1:] {
+		entry.Diff = append(entry.Diff, reportLine{Class: diffLineClass(l), Text: l})
+	}
+
+	e.report(entry)
+}
+
+// reportImage adds a failed image snapshot to the report.
+func (e Val) reportImage(path, msg string, snapshot, current, diff image.Image) {
+	e.report(reportEntry{
+		Path:     path,
+		Message:  msg,
+		Snapshot: dataURL(snapshot),
+		Current:  dataURL(current),
+		DiffImg:  dataURL(diff),
+	})
+}
+
+// report adds the entry and writes the whole report again, so it's complete whenever the run ends.
+func (e Val) report(entry reportEntry) {
+	e.t.Helper()
+
+	path := e.ex.snapshotReport()
+	if path == "" {
+		return
+	}
+
+	if n, is := e.t.(interface{ Name() string }); is {
+		entry.Test = n.Name()
+	}
+
+	snapshotReportsMu.Lock()
+	defer snapshotReportsMu.Unlock()
+
+	snapshotReports[path] = append(snapshotReports[path], entry)
+
+	err := writeReport(path, snapshotReports[path])
+	if err != nil {
+		e.t.Errorf("failed to write snapshot report %v, %v", path, err)
+	}
+}
+
+func writeReport(path string, entries []reportEntry) error {
+	folder := filepath.Dir(path)
+	if folder != "." {
+		err := os.MkdirAll(folder, 0o755)
+		if err != nil {
+			return err
+		}
+	}
+
+	out := bytes.NewBuffer(nil)
+
+	err := reportTemplate.Execute(out, entries)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, out.Bytes(), 0o644)
+}
+
+// diffLineClass returns the css class of a line of a unified diff.
+func diffLineClass(l string) string {
+	switch {
+	case strings.HasPrefix(l, "---"), strings.HasPrefix(l, "+++"), strings.HasPrefix(l, "@@"):
+		return "hunk"
+	case strings.HasPrefix(l, "-"):
+		return "del"
+	case strings.HasPrefix(l, "+"):
+		return "add"
+	}
+
+	return ""
+}
+
+// dataURL encodes the image as png data URL, browsers can't show all snapshot formats.
+func dataURL(img image.Image) template.URL {
+	if img == nil {
+		return ""
+	}
+
+	encoded := bytes.NewBuffer(nil)
+
+	err := png.Encode(encoded, img)
+	if err != nil {
+		return ""
+	}
+
+	return template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(encoded.Bytes()))
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Snapshot report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+section { border-top: 1px solid #ccc; padding: 1em 0; }
+h2 { font-size: 1.1em; margin: 0; }
+.path { color: #666; font-family: monospace; }
+pre { background: #f6f6f6; padding: 0.5em; overflow: auto; }
+.del { background: #fdd; }
+.add { background: #dfd; }
+.hunk { color: #66a; }
+.images { display: flex; gap: 1em; flex-wrap: wrap; align-items: flex-start; }
+.images figure { margin: 0; }
+.slider { position: relative; display: inline-block; }
+.slider img { display: block; }
+.slider .top { position: absolute; top: 0; left: 0; clip-path: inset(0 50% 0 0); }
+.blink .top { animation: blink 1s steps(1) infinite; clip-path: none !important; }
+@keyframes blink { 50% { visibility: hidden; } }
+</style>
+</head>
+<body>
+<h1>{{len .}} snapshot(s) do not match</h1>
+{{range .}}
+<section>
+<h2>{{.Test}}</h2>
+<div class="path">{{.Path}}</div>
+<pre>{{.Message}}</pre>
+{{if .Snapshot}}
+<div class="images">
+<figure>
+<div class="slider">
+<img src="{{.Snapshot}}" alt="snapshot">
+<img class="top" src="{{.Current}}" alt="current">
+</div>
+<figcaption>
+<input type="range" min="0" max="100" value="50" oninput="slide(this)">
+<label><input type="checkbox" onchange="blink(this)"> blink</label>
+current | snapshot
+</figcaption>
+</figure>
+{{if .DiffImg}}<figure><img src="{{.DiffImg}}" alt="diff"><figcaption>diff</figcaption></figure>{{end}}
+</div>
+{{else if .Diff}}
+<pre>{{range .Diff}}<span class="{{.Class}}">{{.Text}}</span>
+{{end}}</pre>
+{{end}}
+</section>
+{{end}}
+<script>
+function slider(e) { return e.closest("figure").querySelector(".slider"); }
+function slide(e) { slider(e).querySelector(".top").style.clipPath = "inset(0 " + (100 - e.value) + "% 0 0)"; }
+function blink(e) { slider(e).classList.toggle("blink", e.checked); }
+</script>
+</body>
+</html>
+`))
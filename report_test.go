@@ -0,0 +1,68 @@
+package expect_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/akabio/expect"
+	"github.com/akabio/expect/internal/test"
+)
+
+func TestSnapshotReport(t *testing.T) {
+	cleanTestData(t)
+
+	ex := &expect.Expect{SnapshotReport: "testdata/volatile/report/index.html"}
+
+	ex.Value(t, "content", "we are all crazy").ToBeSnapshot("testdata/volatile/report.txt")
+
+	_, err := os.Stat("testdata/volatile/report/index-github.com_akabio_expect.html")
+	expect.Value(t, "report exists", os.IsNotExist(err)).ToBe(true)
+
+	test.New(t, func(t expect.Test) {
+		ex.Value(t, "content", "we are all <nuts>").ToBeSnapshot("testdata/volatile/report.txt")
+	})
+
+	data, err := os.ReadFile("testdata/volatile/report/index-github.com_akabio_expect.html")
+	expect.Error(t, err).ToBe(nil)
+
+	report := string(data)
+	expect.Value(t, "report", report).ToContain("<h1>1 snapshot(s) do not match</h1>")
+	expect.Value(t, "report", report).ToContain(`<span class="del">-we are all crazy</span>`)
+	expect.Value(t, "report", report).ToContain(`<span class="add">&#43;we are all &lt;nuts&gt;</span>`)
+}
+
+func TestSnapshotReportImage(t *testing.T) {
+	cleanTestData(t)
+
+	dirty := fixture(t, "sample-dirty.png")
+	t.Setenv(expect.SnapshotReportEnv, "testdata/volatile/report.html")
+
+	test.New(t, func(t expect.Test) {
+		expect.Value(t, "content", sampleImage).ToBeSnapshotImage(dirty, expect.WithExact())
+	})
+
+	data, err := os.ReadFile("testdata/volatile/report-github.com_akabio_expect.html")
+	expect.Error(t, err).ToBe(nil)
+
+	report := string(data)
+	expect.Value(t, "report", report).ToContain(dirty)
+	expect.Value(t, "report", report).ToContain("1.7% of pixels do not match")
+	expect.Value(t, "embedded images", strings.Count(report, `src="data:image/png;base64,`)).ToBe(3)
+}
+
+func TestSnapshotReportRemovesStaleReport(t *testing.T) {
+	cleanTestData(t)
+
+	err := os.MkdirAll("testdata/volatile", 0o755)
+	expect.Error(t, err).ToBe(nil)
+
+	err = os.WriteFile("testdata/volatile/stale-github.com_akabio_expect.html", []byte("failures of an earlier run"), 0o644)
+	expect.Error(t, err).ToBe(nil)
+
+	ex := &expect.Expect{SnapshotReport: "testdata/volatile/stale.html"}
+	ex.Value(t, "content", "all good").ToBeSnapshot("testdata/volatile/stale.txt")
+
+	_, err = os.Stat("testdata/volatile/stale-github.com_akabio_expect.html")
+	expect.Value(t, "report exists", os.IsNotExist(err)).ToBe(true)
+}
@@ -0,0 +1,29 @@
+package expect
+
+// result is the outcome of evaluating an expectation. The failure message is only
+// created when it is needed, negated selects the message for a negated expectation.
+type result struct {
+	pass    bool
+	message func(negated bool) string
+}
+
+// check reports the result to the test. A result without a message is not reported,
+// this is the case when the expectation already failed fatally.
+func (e Val) check(r result) {
+	e.t.Helper()
+
+	if r.message == nil || r.pass != e.negate {
+		return
+	}
+
+	e.t.Error(r.message(e.negate))
+}
+
+// not returns the word used in messages of negated expectations.
+func not(negated bool) string {
+	if negated {
+		return " NOT"
+	}
+
+	return ""
+}
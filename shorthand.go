@@ -0,0 +1,178 @@
+package expect
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ToBeNil asserts that the value is nil or a nil pointer, map, slice, chan or func.
+func (e Val) ToBeNil() Val {
+	e.t.Helper()
+	e.check(e.toBeNil())
+
+	return e
+}
+
+// ToBeNotNil asserts that the value is not nil.
+func (e Val) ToBeNotNil() Val {
+	e.t.Helper()
+	e.Not().check(e.toBeNil())
+
+	return e
+}
+
+func (e Val) toBeNil() result {
+	return result{
+		pass: isNilValue(e.value),
+		message: func(negated bool) string {
+			if negated {
+				return fmt.Sprintf("expected %v NOT to be nil but it is", e.name)
+			}
+
+			return fmt.Sprintf("expected %v to be nil but it is %v", e.name, formatValue(reflect.ValueOf(e.value)))
+		},
+	}
+}
+
+// ToBeEmpty asserts that the array, slice, map, chan or string has no elements. Values of other types
+// must be the zero value.
+func (e Val) ToBeEmpty() Val {
+	e.t.Helper()
+	e.check(e.toBeEmpty())
+
+	return e
+}
+
+func (e Val) toBeEmpty() result {
+	if e.value == nil || !hasLen(e.value) {
+		r := e.toBeZero()
+		r.message = func(negated bool) string {
+			if negated {
+				return fmt.Sprintf("expected %v NOT to be empty but it is", e.name)
+			}
+
+			return fmt.Sprintf("expected %v to be empty but it is %v", e.name, formatValue(reflect.ValueOf(e.value)))
+		}
+
+		return r
+	}
+
+	l := reflect.ValueOf(e.value).Len()
+
+	return result{
+		pass: l == 0,
+		message: func(negated bool) string {
+			if negated {
+				return fmt.Sprintf("expected %v NOT to be empty but it is", e.name)
+			}
+
+			if s, is := e.value.(string); is {
+				return fmt.Sprintf("expected %v to be empty but it is %v", e.name, formatValue(reflect.ValueOf(s)))
+			}
+
+			return fmt.Sprintf("expected %v to be empty but it has %v elements", e.name, l)
+		},
+	}
+}
+
+// ToBeZero asserts that the value is the zero value of its type.
+func (e Val) ToBeZero() Val {
+	e.t.Helper()
+	e.check(e.toBeZero())
+
+	return e
+}
+
+func (e Val) toBeZero() result {
+	return result{
+		pass: e.value == nil || reflect.ValueOf(e.value).IsZero(),
+		message: func(negated bool) string {
+			if negated {
+				return fmt.Sprintf("expected %v NOT to be the zero value but it is", e.name)
+			}
+
+			return fmt.Sprintf("expected %v to be the zero value but it is %v", e.name, formatValue(reflect.ValueOf(e.value)))
+		},
+	}
+}
+
+// ToBeTrue asserts that the bool value is true.
+func (e Val) ToBeTrue() Val {
+	e.t.Helper()
+	e.check(e.toBeBool("ToBeTrue", true))
+
+	return e
+}
+
+// ToBeFalse asserts that the bool value is false.
+func (e Val) ToBeFalse() Val {
+	e.t.Helper()
+	e.check(e.toBeBool("ToBeFalse", false))
+
+	return e
+}
+
+func (e Val) toBeBool(matcher string, expected bool) result {
+	e.t.Helper()
+
+	v := reflect.ValueOf(e.value)
+	if !v.IsValid() || v.Kind() != reflect.Bool {
+		e.t.Fatalf("%v must only be called on a bool value but it is %T", matcher, e.value)
+		return result{}
+	}
+
+	return result{
+		pass: v.Bool() == expected,
+		message: func(negated bool) string {
+			if negated {
+				return fmt.Sprintf("expected %v NOT to be %v but it is", e.name, expected)
+			}
+
+			return fmt.Sprintf("expected %v to be %v but it is %v", e.name, expected, v.Bool())
+		},
+	}
+}
+
+// ToSucceed asserts that the error is nil. On failure the message and type of the error are reported.
+// A nil pointer stored in an error succeeds like in ToBeNil.
+func (e Val) ToSucceed() Val {
+	e.t.Helper()
+	e.check(e.toSucceed())
+
+	return e
+}
+
+func (e Val) toSucceed() result {
+	e.t.Helper()
+
+	err, ok := e.asError("ToSucceed")
+	if !ok {
+		return result{}
+	}
+
+	return result{
+		pass: isNilValue(err),
+		message: func(negated bool) string {
+			if negated {
+				return fmt.Sprintf("expected %v NOT to succeed but it did", e.name)
+			}
+
+			return fmt.Sprintf("expected %v to succeed but it failed with %v", e.name, formatError(err))
+		},
+	}
+}
+
+// isNilValue returns true for nil and nil values of all nillable kinds.
+func isNilValue(a interface{}) bool {
+	if isNil(a) {
+		return true
+	}
+
+	v := reflect.ValueOf(a)
+	switch v.Kind() {
+	case reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		return v.IsNil()
+	}
+
+	return false
+}
@@ -1,19 +1,27 @@
 package expect
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 
-	_ "image/gif"
-	_ "image/jpeg"
-
-	"github.com/ghodss/yaml"
+	"github.com/akabio/expect/internal/textdiff"
 	"golang.org/x/exp/slices"
 )
 
-func (e Val) ToBeSnapshot(path string) Val {
+// ToBeSnapshot saves the value in the first run, in later runs, compares the value to the saved one.
+// If they are not the same it fails with a diff and writes the value to a .current file.
+func (e Val) ToBeSnapshot(path string, opts ...Option) Val {
 	e.t.Helper()
 
+	optOb := newSnapshotOptions(opts)
+	optOb.format = e.snapshotFormat(path, optOb)
+
+	if e.negate {
+		e.t.Fatalf("ToBeSnapshot can not be negated")
+	}
+
 	folder := filepath.Dir(path)
 	if folder != "." {
 		err := os.MkdirAll(folder, 0o755)
@@ -22,14 +30,18 @@ func (e Val) ToBeSnapshot(path string) Val {
 		}
 	}
 
+	useSnapshot(path)
+	e.ex.resetReport()
+
 	existing, err := os.ReadFile(path)
 	if err != nil && !os.IsNotExist(err) {
 		e.t.Fatalf("failed to read snaphsot %v: %v", path, err)
 	}
 
-	current, err := asBytes(e.value)
+	current, err := asBytes(e.value, optOb)
 	if err != nil {
 		e.t.Error(err)
+		return e
 	}
 
 	if existing == nil {
@@ -39,28 +51,89 @@ func (e Val) ToBeSnapshot(path string) Val {
 			e.t.Fatalf("failed to write snapshot %v", path)
 		}
 	} else {
-		if slices.Equal(current, existing) {
+		matched := slices.Equal(current, existing)
+		msg := ""
+
+		if !matched && usesSemanticCompare(path, optOb) {
+			if res, ok := semanticCompare(path, existing, current, e.ex.maxDifferences()); ok {
+				matched = len(res.diffs) == 0
+				msg = res.message(path)
+				current = res.canonical
+			}
+		}
+
+		if matched {
 			// all is well, snapshot is matched, remove a possible current version
 			os.RemoveAll(path + ".current")
+		} else if e.ex.updateSnapshots() {
+			err = os.WriteFile(path, current, 0o644)
+			if err != nil {
+				e.t.Fatalf("failed to write snapshot %v", path)
+			}
+
+			os.RemoveAll(path + ".current")
+			e.logf("updated snapshot %v", path)
 		} else {
-			e.t.Errorf("snapshot for %v does not match current output", path)
+			if msg == "" {
+				msg = fmt.Sprintf("snapshot for %v does not match current output\n%v", path, snapshotDiff(existing, current, path, optOb))
+			}
+
+			e.t.Error(msg)
+			e.reportText(path, msg)
+
 			err = os.WriteFile(path+".current", current, 0o644)
 			if err != nil {
 				e.t.Fatalf("failed to write snapshot %v", path)
 			}
+
+			writeArtifact(path + ".current")
 		}
 	}
 
 	return e
 }
 
-func asBytes(in any) ([]byte, error) {
+// asBytes serializes the value for a snapshot and applies the redactions. Strings and
+// []byte are used as they are, all other values are serialized in the format of the options.
+func asBytes(in any, opts *snapshotOptions) ([]byte, error) {
 	switch t := in.(type) {
 	case []byte:
-		return t, nil
+		return redact(t, opts), nil
 	case string:
-		return []byte(t), nil
-	default:
-		return yaml.Marshal(in)
+		return redact([]byte(t), opts), nil
 	}
+
+	format := opts.format
+	if format == nil {
+		format = YAMLFormat
+	}
+
+	if len(opts.redactFields) > 0 {
+		if format == GoFormat || format == SpewFormat {
+			return nil, errors.New("WithRedactField can not be used with the Go and spew formats, they show the types which redaction replaces")
+		}
+
+		var err error
+
+		in, err = redactFields(in, opts.redactFields)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := format.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
+	return redact(data, opts), nil
+}
+
+// snapshotDiff creates a diff of the snapshot and the current output, binary content is summarized.
+func snapshotDiff(existing, current []byte, path string, opts *snapshotOptions) string {
+	if textdiff.IsBinary(existing) || textdiff.IsBinary(current) {
+		return textdiff.Binary(existing, current, path, path+".current")
+	}
+
+	return textdiff.Unified(string(existing), string(current), path, path+".current", opts.diffContext, opts.diffMaxLines)
 }
@@ -2,9 +2,16 @@ package expect_test
 
 import (
 	"bytes"
+	"flag"
+	"fmt"
 	"image"
+	"image/color"
+	"image/draw"
 	"os"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/akabio/expect"
 	"github.com/akabio/expect/internal/test"
@@ -39,7 +46,12 @@ func TestMismatchSnapshot(t *testing.T) {
 	l := test.New(t, func(t expect.Test) {
 		expect.Value(t, "content", "we are all nuts").ToBeSnapshot("testdata/volatile/ss1.txt")
 	})
-	l.ExpectMessage(0).ToBe("snapshot for testdata/volatile/ss1.txt does not match current output")
+	l.ExpectMessage(0).ToBe(`snapshot for testdata/volatile/ss1.txt does not match current output
+--- testdata/volatile/ss1.txt
++++ testdata/volatile/ss1.txt.current
+@@ -1,1 +1,1 @@
+-we are all crazy
++we are all nuts`)
 
 	data, err := os.ReadFile("testdata/volatile/ss1.txt")
 	expect.Error(t, err).ToBe(nil)
@@ -50,6 +62,69 @@ func TestMismatchSnapshot(t *testing.T) {
 	expect.Value(t, "content", string(data)).ToBe("we are all nuts")
 }
 
+func TestMismatchSnapshotDiffContext(t *testing.T) {
+	cleanTestData(t)
+	lines := []string{}
+	for i := 1; i <= 20; i++ {
+		lines = append(lines, fmt.Sprintf("line %v", i))
+	}
+
+	expect.Value(t, "content", strings.Join(lines, "\n")).ToBeSnapshot("testdata/volatile/ss1.txt")
+
+	lines[2] = "changed 3"
+	lines[4] = "changed 5"
+	lines[15] = "changed 16"
+	lines = append(lines, "line 21")
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "content", strings.Join(lines, "\n")).ToBeSnapshot("testdata/volatile/ss1.txt", expect.WithDiffContext(1))
+		expect.Value(t, "content", strings.Join(lines, "\n")).ToBeSnapshot("testdata/volatile/ss1.txt", expect.WithDiffContext(1), expect.WithDiffMaxLines(4))
+	})
+	l.ExpectMessages().ToCount(2)
+	l.ExpectMessage(0).ToBe(`snapshot for testdata/volatile/ss1.txt does not match current output
+--- testdata/volatile/ss1.txt
++++ testdata/volatile/ss1.txt.current
+@@ -2,5 +2,5 @@
+ line 2
+-line 3
++changed 3
+ line 4
+-line 5
++changed 5
+ line 6
+@@ -15,3 +15,3 @@
+ line 15
+-line 16
++changed 16
+ line 17
+@@ -20,1 +20,2 @@
+ line 20
++line 21`)
+	l.ExpectMessage(1).ToBe(`snapshot for testdata/volatile/ss1.txt does not match current output
+--- testdata/volatile/ss1.txt
++++ testdata/volatile/ss1.txt.current
+@@ -2,5 +2,5 @@
+ line 2
+-line 3
++changed 3
+... 12 more lines`)
+}
+
+func TestMismatchBinarySnapshot(t *testing.T) {
+	cleanTestData(t)
+	expect.Value(t, "content", []byte{0, 1, 2, 3}).ToBeSnapshot("testdata/volatile/ss1.bin")
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "content", []byte{0, 1, 7, 3, 4}).ToBeSnapshot("testdata/volatile/ss1.bin")
+	})
+	l.ExpectMessage(0).ToBe(`snapshot for testdata/volatile/ss1.bin does not match current output
+binary content differs at offset 0x2
+testdata/volatile/ss1.bin: 4 bytes
+testdata/volatile/ss1.bin.current: 5 bytes
+00000000 testdata/volatile/ss1.bin: 00010203
+00000000 testdata/volatile/ss1.bin.current: 0001070304`)
+}
+
 func TestMatchAfterMismatchSnapshot(t *testing.T) {
 	cleanTestData(t)
 	expect.Value(t, "content", "we are all crazy").ToBeSnapshot("testdata/volatile/ss1.txt")
@@ -66,6 +141,58 @@ func TestMatchAfterMismatchSnapshot(t *testing.T) {
 	expect.Error(t, err).Message().ToBe("open testdata/volatile/ss1.txt.current: no such file or directory")
 }
 
+func TestUpdateSnapshot(t *testing.T) {
+	cleanTestData(t)
+	expect.Value(t, "content", "we are all crazy").ToBeSnapshot("testdata/volatile/ss1.txt")
+	test.New(t, func(t expect.Test) {
+		expect.Value(t, "content", "we are all nuts").ToBeSnapshot("testdata/volatile/ss1.txt")
+	})
+
+	l := test.New(t, func(t expect.Test) {
+		ex := &expect.Expect{UpdateSnapshots: true}
+		ex.Value(t, "content", "we are all nuts").ToBeSnapshot("testdata/volatile/ss1.txt")
+	})
+	l.ExpectMessages().ToCount(0)
+	expect.Value(t, "logs", l.Logs).ToBe([]string{"updated snapshot testdata/volatile/ss1.txt"})
+
+	data, err := os.ReadFile("testdata/volatile/ss1.txt")
+	expect.Error(t, err).ToBe(nil)
+	expect.Value(t, "content", string(data)).ToBe("we are all nuts")
+
+	_, err = os.Stat("testdata/volatile/ss1.txt.current")
+	expect.Value(t, "current exists", os.IsNotExist(err)).ToBe(true)
+}
+
+func TestUpdateSnapshotFromEnv(t *testing.T) {
+	cleanTestData(t)
+	expect.Value(t, "content", "we are all crazy").ToBeSnapshot("testdata/volatile/ss1.txt")
+
+	t.Setenv(expect.UpdateSnapshotsEnv, "1")
+	expect.Value(t, "content", "we are all nuts").ToBeSnapshot("testdata/volatile/ss1.txt")
+
+	data, err := os.ReadFile("testdata/volatile/ss1.txt")
+	expect.Error(t, err).ToBe(nil)
+	expect.Value(t, "content", string(data)).ToBe("we are all nuts")
+}
+
+func TestUpdateSnapshotFromFlag(t *testing.T) {
+	cleanTestData(t)
+	expect.Value(t, "content", "we are all crazy").ToBeSnapshot("testdata/volatile/ss1.txt")
+
+	update := flag.Lookup(expect.UpdateSnapshotsFlag)
+	expect.Value(t, "flag", update).ToBeNotNil()
+
+	old := update.Value.String()
+	t.Cleanup(func() { _ = update.Value.Set(old) })
+
+	expect.Error(t, update.Value.Set("true")).ToBe(nil)
+	expect.Value(t, "content", "we are all nuts").ToBeSnapshot("testdata/volatile/ss1.txt")
+
+	data, err := os.ReadFile("testdata/volatile/ss1.txt")
+	expect.Error(t, err).ToBe(nil)
+	expect.Value(t, "content", string(data)).ToBe("we are all nuts")
+}
+
 func TestCreateSnapshotFromBytes(t *testing.T) {
 	cleanTestData(t)
 	expect.Value(t, "content", []byte{1, 2, 3}).ToBeSnapshot("testdata/volatile/ss1.bin")
@@ -95,18 +222,62 @@ func TestCreateSnapshotImage(t *testing.T) {
 func TestCreateSnapshotImageSize(t *testing.T) {
 	cleanTestData(t)
 
+	small := fixture(t, "sample-small.png")
+
 	l := test.New(t, func(t expect.Test) {
-		expect.Value(t, "content", sampleImage).ToBeSnapshotImage("testdata/snapshots/sample-small.png")
+		expect.Value(t, "content", sampleImage).ToBeSnapshotImage(small)
 	})
 	l.ExpectMessages().ToCount(1)
-	l.ExpectMessage(0).ToBe("expected image size to be (64,64) but it is (128,128)")
+	l.ExpectMessage(0).ToBe("expected image size to be (64,64) but it is (128,128), the right edge grew by 64px and the bottom edge grew by 64px")
+
+	// the diff covers both images, pixels outside of the snapshot are mismatches
+	data, err := os.ReadFile("testdata/volatile/sample-small.diff.png")
+	expect.Error(t, err).ToBe(nil)
+
+	diff, _, err := image.Decode(bytes.NewReader(data))
+	expect.Error(t, err).ToBe(nil)
+	expect.Value(t, "diff size", diff.Bounds().Size()).ToBe(image.Pt(128, 128))
+	expect.Value(t, "added pixel", diff.At(100, 100)).ToBe(color.Color(color.RGBA{R: 255, A: 255}))
+}
+
+func TestSnapshotImageSizeTolerance(t *testing.T) {
+	cleanTestData(t)
+
+	cropped := image.NewRGBA(image.Rect(0, 0, 126, 128))
+	draw.Draw(cropped, cropped.Bounds(), sampleImage, image.Point{}, draw.Src)
+	expect.Value(t, "content", cropped).ToBeSnapshotImage("testdata/volatile/cropped.png")
+
+	expect.Value(t, "content", sampleImage).ToBeSnapshotImage("testdata/volatile/cropped.png", expect.WithExact(), expect.WithSizeTolerance(2))
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "content", sampleImage).ToBeSnapshotImage("testdata/volatile/cropped.png", expect.WithSizeTolerance(1))
+	})
+	l.ExpectMessages().ToCount(1)
+	l.ExpectMessage(0).ToBe("expected image size to be (126,128) but it is (128,128), the right edge grew by 2px")
+}
+
+func TestSnapshotImageCrop(t *testing.T) {
+	cleanTestData(t)
+
+	large := image.NewRGBA(image.Rect(0, 0, 140, 100))
+	draw.Draw(large, large.Bounds(), sampleImage, image.Point{}, draw.Src)
+	expect.Value(t, "content", large).ToBeSnapshotImage("testdata/volatile/large.png")
+
+	expect.Value(t, "content", sampleImage).ToBeSnapshotImage("testdata/volatile/large.png", expect.WithExact(), expect.WithCrop())
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "content", sampleImage).ToBeSnapshotImage("testdata/volatile/large.png")
+	})
+	l.ExpectMessage(0).ToBe("expected image size to be (140,100) but it is (128,128), the right edge shrank by 12px and the bottom edge grew by 28px")
 }
 
 func TestCreateSnapshotImageDifferent(t *testing.T) {
 	cleanTestData(t)
 
+	dirty := fixture(t, "sample-dirty.png")
+
 	l := test.New(t, func(t expect.Test) {
-		expect.Value(t, "content", sampleImage).ToBeSnapshotImage("testdata/snapshots/sample-dirty.png", expect.WithExact())
+		expect.Value(t, "content", sampleImage).ToBeSnapshotImage(dirty, expect.WithExact())
 	})
 	l.ExpectMessages().ToCount(1)
 	l.ExpectMessage(0).ToBe("expected image does not match snapshot, 1.7% of pixels do not match")
@@ -115,16 +286,363 @@ func TestCreateSnapshotImageDifferent(t *testing.T) {
 func TestCreateSnapshotImageBlurred(t *testing.T) {
 	cleanTestData(t)
 
+	blured := fixture(t, "sample-blured.png")
+
 	l := test.New(t, func(t expect.Test) {
-		expect.Value(t, "content", sampleImage).ToBeSnapshotImage("testdata/snapshots/sample-blured.png", expect.WithExact())
+		expect.Value(t, "content", sampleImage).ToBeSnapshotImage(blured, expect.WithExact())
 	})
 	l.ExpectMessages().ToCount(1)
 	l.ExpectMessage(0).ToBe("expected image does not match snapshot, 27.8% of pixels do not match")
 }
 
+func TestSnapshotImagePerceptual(t *testing.T) {
+	cleanTestData(t)
+
+	blured := fixture(t, "sample-blured.png")
+	dirty := fixture(t, "sample-dirty.png")
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "content", sampleImage).ToBeSnapshotImage(blured, expect.WithPerceptualDiff(), expect.WithMatchTolerance(0))
+		expect.Value(t, "content", sampleImage).ToBeSnapshotImage(blured, expect.WithPerceptualDiff(), expect.WithMatchTolerance(0.05))
+		expect.Value(t, "content", sampleImage).ToBeSnapshotImage(dirty, expect.WithPerceptualDiff(), expect.WithMatchTolerance(0))
+	})
+	l.ExpectMessages().ToCount(2)
+	l.ExpectMessage(0).ToBe("expected image does not match snapshot, 4.3% of pixels do not match")
+	l.ExpectMessage(1).ToBe("expected image does not match snapshot, 1.3% of pixels do not match")
+}
+
+func TestSnapshotImageSSIM(t *testing.T) {
+	cleanTestData(t)
+
+	blured := fixture(t, "sample-blured.png")
+	dirty := fixture(t, "sample-dirty.png")
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "content", sampleImage).ToBeSnapshotImage(blured, expect.WithMinSSIM(0.98))
+		expect.Value(t, "content", sampleImage).ToBeSnapshotImage(dirty, expect.WithMinSSIM(0.98))
+	})
+	l.ExpectMessages().ToCount(1)
+	l.ExpectMessage(0).ToBe("expected image does not match snapshot, SSIM is 0.9622 but must be at least 0.9800")
+}
+
+func TestSnapshotImageIgnoreRegion(t *testing.T) {
+	cleanTestData(t)
+
+	dirty := fixture(t, "sample-dirty.png")
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "content", sampleImage).ToBeSnapshotImage(dirty, expect.WithExact(),
+			expect.WithIgnoreRegion(image.Rect(28, 20, 110, 112)))
+		expect.Value(t, "content", sampleImage).ToBeSnapshotImage(dirty, expect.WithExact(),
+			expect.WithIgnoreRegion(image.Rect(28, 20, 110, 60)))
+	})
+	l.ExpectMessages().ToCount(1)
+	l.ExpectMessage(0).ToBe("expected image does not match snapshot, 0.9% of pixels do not match")
+}
+
+func TestSnapshotImageMask(t *testing.T) {
+	cleanTestData(t)
+
+	dirty := fixture(t, "sample-dirty.png")
+
+	mask := image.NewAlpha(image.Rect(0, 0, 128, 128))
+	draw.Draw(mask, image.Rect(28, 20, 110, 112), image.Opaque, image.Point{}, draw.Src)
+
+	expect.Value(t, "content", sampleImage).ToBeSnapshotImage(dirty, expect.WithExact(), expect.WithMask(mask))
+
+	// the masked area is hatched in the diff image
+	mask = image.NewAlpha(image.Rect(0, 0, 128, 128))
+	draw.Draw(mask, image.Rect(0, 0, 10, 10), image.Opaque, image.Point{}, draw.Src)
+
+	test.New(t, func(t expect.Test) {
+		expect.Value(t, "content", sampleImage).ToBeSnapshotImage(dirty, expect.WithExact(), expect.WithMask(mask))
+	})
+
+	data, err := os.ReadFile("testdata/volatile/sample-dirty.diff.png")
+	expect.Error(t, err).ToBe(nil)
+
+	diff, _, err := image.Decode(bytes.NewReader(data))
+	expect.Error(t, err).ToBe(nil)
+	expect.Value(t, "hatched pixel", diff.At(0, 0)).ToBe(color.Color(color.RGBA{R: 64, G: 96, B: 255, A: 255}))
+}
+
+func TestSnapshotImageCompare(t *testing.T) {
+	cleanTestData(t)
+
+	dirty := fixture(t, "sample-dirty.png")
+
+	test.New(t, func(t expect.Test) {
+		expect.Value(t, "content", sampleImage).ToBeSnapshotImage(dirty, expect.WithExact(), expect.WithCompareImage())
+	})
+
+	data, err := os.ReadFile("testdata/volatile/sample-dirty.compare.png")
+	expect.Error(t, err).ToBe(nil)
+
+	compare, _, err := image.Decode(bytes.NewReader(data))
+	expect.Error(t, err).ToBe(nil)
+	expect.Value(t, "compare size", compare.Bounds().Size()).ToBe(image.Pt(128*3+8, 128))
+
+	// a mismatching pixel is red, unchanged ones are light gray
+	data, err = os.ReadFile("testdata/volatile/sample-dirty.diff.png")
+	expect.Error(t, err).ToBe(nil)
+
+	diff, _, err := image.Decode(bytes.NewReader(data))
+	expect.Error(t, err).ToBe(nil)
+	expect.Value(t, "unchanged pixel", diff.At(1, 1)).ToBe(color.Color(color.RGBA{R: 255, G: 255, B: 255, A: 255}))
+
+	expect.Value(t, "content", sampleImage).ToBeSnapshotImage(dirty, expect.WithIgnoreRegion(image.Rect(28, 20, 110, 112)))
+
+	_, err = os.Stat("testdata/volatile/sample-dirty.compare.png")
+	expect.Value(t, "compare exists", os.IsNotExist(err)).ToBe(true)
+}
+
+func TestUpdateSnapshotImage(t *testing.T) {
+	cleanTestData(t)
+	expect.Value(t, "content", sampleImage).ToBeSnapshotImage("testdata/volatile/sample.png")
+
+	blank := image.NewRGBA(sampleImage.Bounds())
+	test.New(t, func(t expect.Test) {
+		expect.Value(t, "content", blank).ToBeSnapshotImage("testdata/volatile/sample.png")
+	})
+
+	l := test.New(t, func(t expect.Test) {
+		ex := &expect.Expect{UpdateSnapshots: true}
+		ex.Value(t, "content", blank).ToBeSnapshotImage("testdata/volatile/sample.png")
+	})
+	l.ExpectMessages().ToCount(0)
+	expect.Value(t, "logs", l.Logs).ToBe([]string{"updated snapshot testdata/volatile/sample.png"})
+
+	for _, p := range []string{"testdata/volatile/sample.current.png", "testdata/volatile/sample.diff.png"} {
+		_, err := os.Stat(p)
+		expect.Value(t, p+" exists", os.IsNotExist(err)).ToBe(true)
+	}
+
+	expect.Value(t, "content", blank).ToBeSnapshotImage("testdata/volatile/sample.png", expect.WithExact())
+}
+
 func cleanTestData(t *testing.T) {
 	err := os.RemoveAll("testdata/volatile")
 	if err != nil {
 		t.Fatal("Failed to clear testdata folder")
 	}
 }
+
+// fixture copies a snapshot from testdata/snapshots to testdata/volatile so the tests never
+// write next to the tracked snapshots.
+func fixture(t *testing.T, name string) string {
+	data, err := os.ReadFile("testdata/snapshots/" + name)
+	expect.Error(t, err).ToBe(nil)
+
+	err = os.MkdirAll("testdata/volatile", 0o755)
+	expect.Error(t, err).ToBe(nil)
+
+	path := "testdata/volatile/" + name
+
+	err = os.WriteFile(path, data, 0o644)
+	expect.Error(t, err).ToBe(nil)
+
+	return path
+}
+
+func TestMatchInlineSnapshot(t *testing.T) {
+	expect.Value(t, "content", "we are all crazy").ToMatchInlineSnapshot(`we are all crazy`)
+	expect.Value(t, "content", map[string]int{"a": 1}).ToMatchInlineSnapshot(`a: 1
+`)
+}
+
+func TestMismatchInlineSnapshot(t *testing.T) {
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "content", "a\nb\nc").ToMatchInlineSnapshot("a\nx\nc")
+	})
+	l.ExpectMessages().ToCount(1)
+	l.ExpectMessage(0).ToBe(`expected content to match inline snapshot
+--- inline snapshot
++++ current
+@@ -1,3 +1,3 @@
+ a
+-x
++b
+ c`)
+
+	l = test.New(t, func(t expect.Test) {
+		expect.Value(t, "content", "a\n").ToMatchInlineSnapshot("a")
+	})
+	l.ExpectMessages().ToCount(1)
+	l.ExpectMessage(0).ToBe(`expected content to match inline snapshot
+--- inline snapshot
++++ current
+(only the line endings differ)`)
+}
+
+type apiResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	Tags      []apiTag  `json:"tags"`
+}
+
+type apiTag struct {
+	Secret string
+	Label  string
+}
+
+func TestSnapshotRedaction(t *testing.T) {
+	cleanTestData(t)
+	expect.Value(t, "content", "id 0b7c6a8e-3b8e-4c0f-9d6e-2f1c3a4b5c6d at 2023-08-01T11:50:18.123Z, key=abc").
+		ToBeSnapshot("testdata/volatile/redact.txt",
+			expect.WithRedactUUIDs(),
+			expect.WithRedactTimestamps(),
+			expect.WithRedact(regexp.MustCompile(`key=\w+`), "key=***"),
+		)
+
+	data, err := os.ReadFile("testdata/volatile/redact.txt")
+	expect.Error(t, err).ToBe(nil)
+	expect.Value(t, "content", string(data)).ToBe("id <uuid> at <timestamp>, key=***")
+}
+
+func TestSnapshotRedactField(t *testing.T) {
+	cleanTestData(t)
+
+	for i := 0; i < 2; i++ {
+		expect.Value(t, "response", apiResponse{
+			ID:        fmt.Sprintf("id-%v", i),
+			Name:      "peter",
+			CreatedAt: time.Now(),
+			Tags:      []apiTag{{Secret: fmt.Sprintf("s%v", i), Label: "a"}},
+		}).ToBeSnapshot("testdata/volatile/redact.yaml", expect.WithRedactField("createdAt"), expect.WithRedactField("ID"), expect.WithRedactField("secret"))
+	}
+
+	data, err := os.ReadFile("testdata/volatile/redact.yaml")
+	expect.Error(t, err).ToBe(nil)
+	expect.Value(t, "content", string(data)).ToBe(`createdAt: <redacted>
+id: <redacted>
+name: peter
+tags:
+- Label: a
+  Secret: <redacted>
+`)
+}
+
+func TestSnapshotRedactFieldKeepsNumbers(t *testing.T) {
+	cleanTestData(t)
+
+	expect.Value(t, "response", map[string]any{"id": int64(9007199254740993), "secret": "s"}).
+		ToBeSnapshot("testdata/volatile/redact.json", expect.WithRedactField("secret"))
+
+	data, err := os.ReadFile("testdata/volatile/redact.json")
+	expect.Error(t, err).ToBe(nil)
+	expect.Value(t, "content", string(data)).ToBe(`{
+  "id": 9007199254740993,
+  "secret": "<redacted>"
+}
+`)
+}
+
+func TestSnapshotRedactFieldGoFormat(t *testing.T) {
+	cleanTestData(t)
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "response", apiTag{Secret: "s"}).
+			ToBeSnapshot("testdata/volatile/redact.spew", expect.WithRedactField("secret"))
+	})
+	l.ExpectMessages().ToCount(1)
+	l.ExpectMessage(0).ToBe("WithRedactField can not be used with the Go and spew formats, they show the types which redaction replaces")
+
+	_, err := os.Stat("testdata/volatile/redact.spew")
+	expect.Value(t, "snapshot exists", os.IsNotExist(err)).ToBe(true)
+}
+
+func TestSemanticSnapshotJSON(t *testing.T) {
+	cleanTestData(t)
+	err := os.MkdirAll("testdata/volatile", 0o755)
+	expect.Error(t, err).ToBe(nil)
+
+	err = os.WriteFile("testdata/volatile/order.json", []byte(`{"items": [{"price": 4.5, "name": "pear"}], "id": 1,
+		"labels": {"env": "prod", "my key": 1}}`), 0o644)
+	expect.Error(t, err).ToBe(nil)
+
+	// same content with different formatting and key order
+	expect.Value(t, "order", `{"id":1,"labels":{"my key":1,"env":"prod"},"items":[{"name":"pear","price":4.5}]}`).
+		ToBeSnapshot("testdata/volatile/order.json")
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "order", `{"id":1,"labels":{"my key":2},"items":[{"name":"pear","price":4}]}`).
+			ToBeSnapshot("testdata/volatile/order.json")
+	})
+	l.ExpectMessages().ToCount(1)
+	l.ExpectMessage(0).ToBe(`expected snapshot testdata/volatile/order.json to be equal but it has 3 differences
+    $.items[0].price: expected 4.5 but it is 4
+    $.labels.env: missing
+    $.labels["my key"]: expected 1 but it is 2`)
+
+	expect.Value(t, "current", readSnapshot(t, "testdata/volatile/order.json.current")).ToBe(`{
+  "id": 1,
+  "items": [
+    {
+      "name": "pear",
+      "price": 4
+    }
+  ],
+  "labels": {
+    "my key": 2
+  }
+}
+`)
+}
+
+func TestSemanticSnapshotNumbers(t *testing.T) {
+	cleanTestData(t)
+	expect.Value(t, "ids", `{"id": 18446744073709551617, "price": 1.50, "tiny": 1e-1000000}`).ToBeSnapshot("testdata/volatile/ids.json")
+	expect.Value(t, "ids", `{"id": 18446744073709551617, "price": 1.5, "tiny": 0.1e-999999}`).ToBeSnapshot("testdata/volatile/ids.json")
+
+	// both values are the same float64
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "ids", `{"id": 18446744073709551616, "price": 1.50000000000000000001, "tiny": 1e-1000000}`).
+			ToBeSnapshot("testdata/volatile/ids.json")
+	})
+	l.ExpectMessages().ToCount(1)
+	l.ExpectMessage(0).ToBe(`expected snapshot testdata/volatile/ids.json to be equal but it has 2 differences
+    $.id: expected 18446744073709551617 but it is 18446744073709551616
+    $.price: expected 1.5 but it is 1.50000000000000000001`)
+
+	expect.Value(t, "current", readSnapshot(t, "testdata/volatile/ids.json.current")).ToBe(`{
+  "id": 18446744073709551616,
+  "price": 1.50000000000000000001,
+  "tiny": 1e-1000000
+}
+`)
+}
+
+func TestSemanticSnapshotYAMLNumbers(t *testing.T) {
+	cleanTestData(t)
+	expect.Value(t, "ids", "id: 18446744073709551617\nprice: 1.50\nmask: 0xff\n").ToBeSnapshot("testdata/volatile/ids.yaml")
+	expect.Value(t, "ids", "mask: 255\nprice: 1.5\nid: 18446744073709551617\n").ToBeSnapshot("testdata/volatile/ids.yaml")
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "ids", "id: 18446744073709551616\nprice: 1.50000000000000000001\nmask: 255\n").
+			ToBeSnapshot("testdata/volatile/ids.yaml")
+	})
+	l.ExpectMessages().ToCount(1)
+	l.ExpectMessage(0).ToBe(`expected snapshot testdata/volatile/ids.yaml to be equal but it has 2 differences
+    $.id: expected 18446744073709551617 but it is 18446744073709551616
+    $.price: expected 1.5 but it is 1.50000000000000000001`)
+
+	expect.Value(t, "current", readSnapshot(t, "testdata/volatile/ids.yaml.current")).
+		ToBe("id: 18446744073709551616\nprice: 1.50000000000000000001\nmask: 255\n")
+}
+
+func TestSemanticSnapshotYAML(t *testing.T) {
+	cleanTestData(t)
+	expect.Value(t, "content", map[string]int{"a": 1, "b": 2}).ToBeSnapshot("testdata/volatile/map.yaml")
+	expect.Value(t, "content", "b: 2\na:   1\n").ToBeSnapshot("testdata/volatile/map.yaml")
+}
+
+func TestSemanticSnapshotDisabled(t *testing.T) {
+	cleanTestData(t)
+	expect.Value(t, "content", `{"a":1}`).ToBeSnapshot("testdata/volatile/a.json")
+
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "content", `{"a": 1}`).ToBeSnapshot("testdata/volatile/a.json", expect.WithSemanticCompare(false))
+	})
+	l.ExpectMessages().ToCount(1)
+}
@@ -0,0 +1,232 @@
+package expect
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/akabio/expect/internal/imageext"
+)
+
+var (
+	snapshotFilesLock sync.Mutex
+	// usedSnapshots contains the absolute paths of all snapshots used in this run
+	usedSnapshots = map[string]bool{}
+	// writtenArtifacts contains the absolute paths of all .current and .diff files written in this run
+	writtenArtifacts = map[string]bool{}
+	// namedTests contains the absolute path prefixes of automatically named snapshots of the tests
+	// which ran, like testdata/snapshots/TestName for testdata/snapshots/TestName-1.snap
+	namedTests = map[string]bool{}
+)
+
+// artifactSuffixes are the suffixes of files written next to a snapshot when it does not match.
+var artifactSuffixes = append([]string{".current"}, imageArtifactSuffixes()...)
+
+// automaticName matches the counter and extension of automatically named snapshots.
+var automaticName = regexp.MustCompile(`-\d+\.[^.]+$`)
+
+// Runner is implemented by *testing.M.
+type Runner interface {
+	Run() int
+}
+
+// CheckOption configures CheckSnapshots.
+type CheckOption func(*checkOptions)
+
+type checkOptions struct {
+	failOnUnused bool
+}
+
+// WithFailOnUnused makes CheckSnapshots fail a passing run with a non-zero exit code when unused
+// snapshot files are found.
+func WithFailOnUnused() CheckOption {
+	return func(o *checkOptions) {
+		o.failOnUnused = true
+	}
+}
+
+// CheckSnapshots runs the tests and afterwards reports all snapshots in root which were not used and
+// left over .current, .current.png and .diff.png files from earlier runs. Only files with the
+// extension of a snapshot format, .txt or an image extension are checked. Automatically named
+// snapshots of tests which did not run, because they were skipped or removed, are not reported.
+// In update mode the unused files are deleted, but only when all tests passed and none was skipped
+// with -short. With WithFailOnUnused unused files fail a passing run.
+// Intended to be called from TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(expect.CheckSnapshots(m, "testdata/snapshots"))
+//	}
+//
+// The check is skipped when only a part of the tests is run with -run or -skip.
+func CheckSnapshots(m Runner, root string, opts ...CheckOption) int {
+	o := &checkOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	code := m.Run()
+
+	if partialRun() {
+		return code
+	}
+
+	unused, err := unusedSnapshotFiles(root)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to check snapshots in %v, %v\n", root, err)
+		return code
+	}
+
+	if len(unused) == 0 {
+		return code
+	}
+
+	// failed tests, including the ones not run after a -failfast failure, and tests skipped with
+	// -short did not use their snapshots, so their files are not unused
+	complete := code == 0 && !shortRun()
+	update := Default.updateSnapshots()
+
+	for _, file := range unused {
+		if update && complete {
+			err = os.Remove(file)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "failed to delete unused snapshot file %v, %v\n", file, err)
+			} else {
+				fmt.Fprintf(os.Stderr, "deleted unused snapshot file %v\n", file)
+			}
+		} else {
+			fmt.Fprintf(os.Stderr, "unused snapshot file %v\n", file)
+		}
+	}
+
+	switch {
+	case update && !complete:
+		fmt.Fprintf(os.Stderr, "unused snapshot files are only deleted when all tests run and pass\n")
+	case !update && code == 0 && o.failOnUnused:
+		return 1
+	}
+
+	return code
+}
+
+// partialRun returns true if the tests are filtered with -run or -skip.
+func partialRun() bool {
+	for _, name := range []string{"test.run", "test.skip"} {
+		if f := flag.Lookup(name); f != nil && f.Value.String() != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// shortRun returns true if the tests run with -short.
+func shortRun() bool {
+	f := flag.Lookup("test.short")
+
+	return f != nil && f.Value.String() == "true"
+}
+
+// unusedSnapshotFiles returns all snapshots in root not used in this run and all
+// .current and .diff files not written in this run.
+func unusedSnapshotFiles(root string) ([]string, error) {
+	snapshotFilesLock.Lock()
+	defer snapshotFilesLock.Unlock()
+
+	unused := []string{}
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == root {
+				return filepath.SkipDir
+			}
+
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case isSnapshotArtifact(path):
+			if !writtenArtifacts[abs] {
+				unused = append(unused, path)
+			}
+		case !isSnapshotFile(path) || usedSnapshots[abs]:
+			// other files like .gitkeep and used snapshots
+		case automaticName.MatchString(abs) && !namedTests[automaticName.ReplaceAllString(abs, "")]:
+			// the test of this snapshot did not run
+		default:
+			unused = append(unused, path)
+		}
+
+		return nil
+	})
+
+	sort.Strings(unused)
+
+	return unused, err
+}
+
+// isSnapshotFile returns true for files with the extension of a snapshot format, .txt or an image.
+func isSnapshotFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".snap" || ext == ".txt" || formatForPath(path) != nil {
+		return true
+	}
+
+	for _, e := range imageext.Extensions {
+		if ext == e {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isSnapshotArtifact(path string) bool {
+	for _, s := range artifactSuffixes {
+		if strings.HasSuffix(path, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// useSnapshot records that the snapshot at path was used in this run.
+func useSnapshot(path string) {
+	recordSnapshotFile(usedSnapshots, path)
+}
+
+// runNamedTest records that a test with automatically named snapshots at the path prefix ran.
+func runNamedTest(prefix string) {
+	recordSnapshotFile(namedTests, prefix)
+}
+
+// writeArtifact records that the .current or .diff file at path was written in this run.
+func writeArtifact(path string) {
+	recordSnapshotFile(writtenArtifacts, path)
+}
+
+func recordSnapshotFile(files map[string]bool, path string) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return
+	}
+
+	snapshotFilesLock.Lock()
+	files[abs] = true
+	snapshotFilesLock.Unlock()
+}
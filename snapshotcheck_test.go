@@ -0,0 +1,129 @@
+package expect_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/akabio/expect"
+	"github.com/akabio/expect/internal/test"
+)
+
+type runner func() int
+
+func (r runner) Run() int {
+	return r()
+}
+
+func writeFiles(t *testing.T, root string, files ...string) {
+	t.Helper()
+
+	for _, f := range files {
+		err := os.WriteFile(filepath.Join(root, f), []byte(f), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestUnusedSnapshotFiles(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, "used.txt", "used.txt.current", "failed.txt", "unused.txt", "stale.current.png", "stale.diff.png",
+		".gitkeep", "README.md", "TestNotRun-1.snap", "TestUnusedSnapshotFiles-2.snap")
+
+	code := expect.CheckSnapshots(runner(func() int {
+		expect.Value(t, "used", "used.txt").ToBeSnapshot(filepath.Join(root, "used.txt"))
+		(&expect.Expect{SnapshotRoot: root}).Value(t, "named", "named").ToMatchSnapshot()
+
+		test.New(t, func(t expect.Test) {
+			expect.Value(t, "failed", "other").ToBeSnapshot(filepath.Join(root, "failed.txt"))
+		})
+
+		return 3
+	}), root)
+	expect.Value(t, "exit code", code).ToBe(3)
+
+	unused, err := expect.UnusedSnapshotFiles(root)
+	expect.Error(t, err).ToBe(nil)
+	expect.Value(t, "unused", unused).ToBe([]string{
+		filepath.Join(root, "TestUnusedSnapshotFiles-2.snap"),
+		filepath.Join(root, "stale.current.png"),
+		filepath.Join(root, "stale.diff.png"),
+		filepath.Join(root, "unused.txt"),
+	})
+}
+
+func TestCheckSnapshotsDeletesInUpdateMode(t *testing.T) {
+	if expect.PartialRun() || expect.ShortRun() {
+		t.Skip("CheckSnapshots does not delete in a partial run")
+	}
+
+	root := t.TempDir()
+	writeFiles(t, root, "used.txt", "unused.txt", "stale.txt.current")
+
+	t.Setenv(expect.UpdateSnapshotsEnv, "1")
+
+	code := expect.CheckSnapshots(runner(func() int {
+		expect.Value(t, "used", "used.txt").ToBeSnapshot(filepath.Join(root, "used.txt"))
+		return 0
+	}), root)
+	expect.Value(t, "exit code", code).ToBe(0)
+
+	entries, err := os.ReadDir(root)
+	expect.Error(t, err).ToBe(nil)
+	expect.Value(t, "files", entries).ToCount(1)
+	expect.Value(t, "file", entries[0].Name()).ToBe("used.txt")
+}
+
+func TestCheckSnapshotsKeepsFilesOfFailedRun(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, "used.txt", "unused.txt")
+
+	t.Setenv(expect.UpdateSnapshotsEnv, "1")
+
+	code := expect.CheckSnapshots(runner(func() int {
+		expect.Value(t, "used", "used.txt").ToBeSnapshot(filepath.Join(root, "used.txt"))
+		return 1
+	}), root)
+	expect.Value(t, "exit code", code).ToBe(1)
+
+	entries, err := os.ReadDir(root)
+	expect.Error(t, err).ToBe(nil)
+	expect.Value(t, "files", entries).ToCount(2)
+}
+
+func TestCheckSnapshotsReportsUnusedFiles(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, "used.txt", "unused.txt")
+
+	code := expect.CheckSnapshots(runner(func() int {
+		expect.Value(t, "used", "used.txt").ToBeSnapshot(filepath.Join(root, "used.txt"))
+		return 0
+	}), root)
+	expect.Value(t, "exit code", code).ToBe(0)
+
+	entries, err := os.ReadDir(root)
+	expect.Error(t, err).ToBe(nil)
+	expect.Value(t, "files", entries).ToCount(2)
+}
+
+func TestCheckSnapshotsFailsOnUnusedFiles(t *testing.T) {
+	if expect.PartialRun() {
+		t.Skip("CheckSnapshots does not check a partial run")
+	}
+
+	root := t.TempDir()
+	writeFiles(t, root, "used.txt", "unused.txt")
+
+	code := expect.CheckSnapshots(runner(func() int {
+		expect.Value(t, "used", "used.txt").ToBeSnapshot(filepath.Join(root, "used.txt"))
+		return 0
+	}), root, expect.WithFailOnUnused())
+	expect.Value(t, "exit code", code).ToBe(1)
+}
+
+func TestUnusedSnapshotFilesMissingRoot(t *testing.T) {
+	unused, err := expect.UnusedSnapshotFiles(filepath.Join(t.TempDir(), "missing"))
+	expect.Error(t, err).ToBe(nil)
+	expect.Value(t, "unused", unused).ToCount(0)
+}
@@ -0,0 +1,310 @@
+package expect
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/ghodss/yaml"
+)
+
+// SnapshotFormat serializes values which are not a string or []byte for snapshots.
+type SnapshotFormat interface {
+	Marshal(v any) ([]byte, error)
+}
+
+// FormatFunc is a custom SnapshotFormat.
+type FormatFunc func(v any) ([]byte, error)
+
+// Marshal calls f.
+func (f FormatFunc) Marshal(v any) ([]byte, error) {
+	return f(v)
+}
+
+// builtinFormat is a format provided by this package, the extension is used to
+// infer the format from snapshot paths and to name automatic snapshots.
+type builtinFormat struct {
+	extensions []string
+	marshal    func(v any) ([]byte, error)
+}
+
+func (f *builtinFormat) Marshal(v any) ([]byte, error) {
+	return f.marshal(v)
+}
+
+var (
+	// JSONFormat serializes values as indented JSON. Used for .json snapshots.
+	JSONFormat SnapshotFormat = &builtinFormat{extensions: []string{".json"}, marshal: marshalJSON}
+	// YAMLFormat serializes values as YAML, this is the default format. Used for .yaml and .yml snapshots.
+	YAMLFormat SnapshotFormat = &builtinFormat{extensions: []string{".yaml", ".yml"}, marshal: yaml.Marshal}
+	// GoFormat serializes values in Go syntax with sorted map keys. Used for .gosyntax snapshots, a
+	// .go extension would make the go tool compile the snapshots.
+	GoFormat SnapshotFormat = &builtinFormat{extensions: []string{".gosyntax"}, marshal: marshalGo}
+	// SpewFormat serializes values with spew including all type information. Used for .spew snapshots.
+	SpewFormat SnapshotFormat = &builtinFormat{extensions: []string{".spew"}, marshal: marshalSpew}
+)
+
+var builtinFormats = []SnapshotFormat{JSONFormat, YAMLFormat, GoFormat, SpewFormat}
+
+// formatForPath returns the format matching the extension of path or nil.
+func formatForPath(path string) SnapshotFormat {
+	ext := strings.ToLower(filepath.Ext(path))
+
+	for _, f := range builtinFormats {
+		for _, e := range f.(*builtinFormat).extensions {
+			if e == ext {
+				return f
+			}
+		}
+	}
+
+	return nil
+}
+
+// formatExtension returns the file extension used for automatically named snapshots.
+func formatExtension(f SnapshotFormat) string {
+	if b, is := f.(*builtinFormat); is {
+		return b.extensions[0]
+	}
+
+	return ".snap"
+}
+
+// snapshotFormat selects the format by option, path extension, the default of the Expect
+// instance or falls back to YAML.
+func (e Val) snapshotFormat(path string, opts *snapshotOptions) SnapshotFormat {
+	if opts.format != nil {
+		return opts.format
+	}
+
+	if f := formatForPath(path); f != nil {
+		return f
+	}
+
+	if e.ex.SnapshotFormat != nil {
+		return e.ex.SnapshotFormat
+	}
+
+	return YAMLFormat
+}
+
+// marshalJSON does not escape <, > and & to keep placeholders like <redacted> readable.
+func marshalJSON(v any) ([]byte, error) {
+	out := bytes.NewBuffer(nil)
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+
+	err := enc.Encode(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
+
+var spewConfig = spew.ConfigState{
+	Indent:                  "  ",
+	SortKeys:                true,
+	SpewKeys:                true,
+	DisableCapacities:       true,
+	DisablePointerAddresses: true,
+}
+
+func marshalSpew(v any) ([]byte, error) {
+	return []byte(spewConfig.Sdump(v)), nil
+}
+
+func marshalGo(v any) ([]byte, error) {
+	g := &goPrinter{visited: map[uintptr]bool{}}
+	g.print(reflect.ValueOf(v), 0)
+	g.b.WriteString("\n")
+
+	return []byte(g.b.String()), nil
+}
+
+// goPrinter prints values in Go syntax, one field or element per line.
+type goPrinter struct {
+	b       strings.Builder
+	visited map[uintptr]bool
+}
+
+func (g *goPrinter) print(v reflect.Value, depth int) {
+	if !v.IsValid() {
+		g.b.WriteString("nil")
+		return
+	}
+
+	t := v.Type()
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			g.b.WriteString("nil")
+			return
+		}
+
+		g.print(v.Elem(), depth)
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			fmt.Fprintf(&g.b, "(%v)(nil)", t)
+			return
+		}
+
+		if g.visited[v.Pointer()] {
+			g.b.WriteString("<cycle>")
+			return
+		}
+
+		g.visited[v.Pointer()] = true
+		defer delete(g.visited, v.Pointer())
+
+		g.b.WriteString("&")
+		g.print(v.Elem(), depth)
+
+	case reflect.Struct:
+		if t == timeType {
+			if v.CanInterface() {
+				fmt.Fprintf(&g.b, "time.Time(%q)", v.Interface().(time.Time).Format(time.RFC3339Nano))
+			} else {
+				fmt.Fprintf(&g.b, "time.Time(%q)", fmt.Sprint(v))
+			}
+
+			return
+		}
+
+		g.b.WriteString(t.String())
+
+		if v.NumField() == 0 {
+			g.b.WriteString("{}")
+			return
+		}
+
+		g.b.WriteString("{\n")
+
+		for i := 0; i < v.NumField(); i++ {
+			g.indent(depth + 1)
+			g.b.WriteString(t.Field(i).Name + ": ")
+			g.print(v.Field(i), depth+1)
+			g.b.WriteString(",\n")
+		}
+
+		g.indent(depth)
+		g.b.WriteString("}")
+
+	case reflect.Map:
+		if v.IsNil() {
+			fmt.Fprintf(&g.b, "%v(nil)", t)
+			return
+		}
+
+		g.b.WriteString(t.String())
+
+		if v.Len() == 0 {
+			g.b.WriteString("{}")
+			return
+		}
+
+		type entry struct {
+			key, value string
+		}
+
+		entries := []entry{}
+
+		for _, k := range v.MapKeys() {
+			kp := &goPrinter{visited: g.visited}
+			kp.print(k, depth+1)
+
+			vp := &goPrinter{visited: g.visited}
+			vp.print(v.MapIndex(k), depth+1)
+
+			entries = append(entries, entry{key: kp.b.String(), value: vp.b.String()})
+		}
+
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].key < entries[j].key
+		})
+
+		g.b.WriteString("{\n")
+
+		for _, e := range entries {
+			g.indent(depth + 1)
+			g.b.WriteString(e.key + ": " + e.value + ",\n")
+		}
+
+		g.indent(depth)
+		g.b.WriteString("}")
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			fmt.Fprintf(&g.b, "%v(nil)", t)
+			return
+		}
+
+		g.b.WriteString(t.String())
+
+		if v.Len() == 0 {
+			g.b.WriteString("{}")
+			return
+		}
+
+		g.b.WriteString("{\n")
+
+		for i := 0; i < v.Len(); i++ {
+			g.indent(depth + 1)
+			g.print(v.Index(i), depth+1)
+			g.b.WriteString(",\n")
+		}
+
+		g.indent(depth)
+		g.b.WriteString("}")
+
+	case reflect.String:
+		g.basic(t, strconv.Quote(v.String()))
+	case reflect.Bool:
+		g.basic(t, strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		g.basic(t, strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		g.basic(t, strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		g.basic(t, strconv.FormatFloat(v.Float(), 'g', -1, t.Bits()))
+	case reflect.Complex64, reflect.Complex128:
+		g.basic(t, strconv.FormatComplex(v.Complex(), 'g', -1, t.Bits()))
+
+	default:
+		// functions, channels and unsafe pointers have no stable representation
+		if v.IsNil() {
+			fmt.Fprintf(&g.b, "(%v)(nil)", t)
+		} else {
+			fmt.Fprintf(&g.b, "(%v)(...)", t)
+		}
+	}
+}
+
+// basic writes a literal, named types are converted to their type.
+func (g *goPrinter) basic(t reflect.Type, literal string) {
+	if t.PkgPath() == "" {
+		switch t.Kind() {
+		case reflect.Int, reflect.String, reflect.Bool, reflect.Float64:
+			// types of untyped constants
+			g.b.WriteString(literal)
+			return
+		}
+	}
+
+	g.b.WriteString(t.String() + "(" + literal + ")")
+}
+
+func (g *goPrinter) indent(depth int) {
+	g.b.WriteString(strings.Repeat("\t", depth))
+}
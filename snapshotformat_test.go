@@ -0,0 +1,146 @@
+package expect_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/akabio/expect"
+)
+
+type formatSample struct {
+	Name   string
+	Count  int
+	Ratio  float32
+	Labels map[string]uint
+	Items  []*formatItem
+	Any    interface{}
+	hidden bool
+}
+
+type formatItem struct {
+	ID int
+}
+
+// custom is serialized with a custom MarshalJSON.
+type custom struct {
+	v int
+}
+
+func (c custom) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]int{"value": c.v})
+}
+
+var sample = formatSample{
+	Name:   "peter",
+	Count:  3,
+	Ratio:  0.5,
+	Labels: map[string]uint{"b": 2, "a": 1},
+	Items:  []*formatItem{{ID: 1}, nil},
+	Any:    custom{v: 7},
+	hidden: true,
+}
+
+func readSnapshot(t *testing.T, path string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	expect.Error(t, err).ToBe(nil)
+
+	return string(data)
+}
+
+func TestSnapshotFormatJSON(t *testing.T) {
+	cleanTestData(t)
+	expect.Value(t, "sample", sample).ToBeSnapshot("testdata/volatile/sample.json")
+	expect.Value(t, "snapshot", readSnapshot(t, "testdata/volatile/sample.json")).ToBe(`{
+  "Name": "peter",
+  "Count": 3,
+  "Ratio": 0.5,
+  "Labels": {
+    "a": 1,
+    "b": 2
+  },
+  "Items": [
+    {
+      "ID": 1
+    },
+    null
+  ],
+  "Any": {
+    "value": 7
+  }
+}
+`)
+}
+
+func TestSnapshotFormatGo(t *testing.T) {
+	cleanTestData(t)
+	expect.Value(t, "sample", sample).ToBeSnapshot("testdata/volatile/sample.txt", expect.WithFormat(expect.GoFormat))
+	expect.Value(t, "snapshot", readSnapshot(t, "testdata/volatile/sample.txt")).ToBe(`expect_test.formatSample{
+	Name: "peter",
+	Count: 3,
+	Ratio: float32(0.5),
+	Labels: map[string]uint{
+		"a": uint(1),
+		"b": uint(2),
+	},
+	Items: []*expect_test.formatItem{
+		&expect_test.formatItem{
+			ID: 1,
+		},
+		(*expect_test.formatItem)(nil),
+	},
+	Any: expect_test.custom{
+		v: 7,
+	},
+	hidden: true,
+}
+`)
+}
+
+func TestSnapshotFormatSpew(t *testing.T) {
+	cleanTestData(t)
+	expect.Value(t, "sample", []*formatItem{{ID: 1}}).ToBeSnapshot("testdata/volatile/sample.spew")
+	expect.Value(t, "snapshot", readSnapshot(t, "testdata/volatile/sample.spew")).ToBe(`([]*expect_test.formatItem) (len=1) {
+  (*expect_test.formatItem)({
+    ID: (int) 1
+  })
+}
+`)
+}
+
+func TestSnapshotFormatCustom(t *testing.T) {
+	cleanTestData(t)
+
+	upper := expect.FormatFunc(func(v any) ([]byte, error) {
+		return []byte("custom"), nil
+	})
+
+	ex := &expect.Expect{SnapshotFormat: upper}
+	ex.Value(t, "sample", sample).ToBeSnapshot("testdata/volatile/sample.txt")
+	expect.Value(t, "snapshot", readSnapshot(t, "testdata/volatile/sample.txt")).ToBe("custom")
+
+	// the extension takes precedence over the default of the Expect instance
+	ex.Value(t, "sample", formatItem{ID: 2}).ToBeSnapshot("testdata/volatile/sample.yaml")
+	expect.Value(t, "snapshot", readSnapshot(t, "testdata/volatile/sample.yaml")).ToBe("ID: 2\n")
+}
+
+func TestMatchSnapshotFormatExtension(t *testing.T) {
+	cleanTestData(t)
+
+	ex := &expect.Expect{SnapshotRoot: "testdata/volatile"}
+	ex.Value(t, "sample", formatItem{ID: 2}).ToMatchSnapshot(expect.WithFormat(expect.JSONFormat))
+	expect.Value(t, "snapshot", readSnapshot(t, "testdata/volatile/TestMatchSnapshotFormatExtension-1.json")).ToBe("{\n  \"ID\": 2\n}\n")
+}
+
+func TestMatchSnapshotGoFormatExtension(t *testing.T) {
+	cleanTestData(t)
+
+	ex := &expect.Expect{SnapshotRoot: "testdata/volatile"}
+	ex.Value(t, "sample", formatItem{ID: 2}).ToMatchSnapshot(expect.WithFormat(expect.GoFormat))
+	expect.Value(t, "snapshot", readSnapshot(t, "testdata/volatile/TestMatchSnapshotGoFormatExtension-1.gosyntax")).ToBe("expect_test.formatItem{\n\tID: 2,\n}\n")
+
+	ex.Value(t, "sample", formatItem{ID: 3}).ToBeSnapshot("testdata/volatile/sample.gosyntax")
+	expect.Value(t, "snapshot", readSnapshot(t, "testdata/volatile/sample.gosyntax")).ToBe("expect_test.formatItem{\n\tID: 3,\n}\n")
+}
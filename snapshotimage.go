@@ -3,84 +3,31 @@ package expect
 import (
 	"bytes"
 	"image"
-	"image/color"
-	"image/png"
-	"math"
 	"os"
 	"path/filepath"
 	"strings"
-)
-
-type Option interface {
-	apply(o *snapshotImageOptions)
-}
-
-type snapshotImageOptions struct {
-	pixelTolerance float64
-	matchTolerance float64
-}
-
-type options struct {
-	pixelTolerance *float64
-	matchTolerance *float64
-}
-
-func WithExact() Option {
-	return &options{
-		pixelTolerance: fptr(0),
-		matchTolerance: fptr(0),
-	}
-}
-
-// WithPixelTolerance sets the maximum allowed color difference for a pixel to be considered a match.
-// 0 means exact match only. 1 means all pixels matched.
-func WithPixelTolerance(t float64) Option {
-	return &options{
-		pixelTolerance: fptr(t),
-	}
-}
-
-// WithMatchTolerance sets the maximum fraction of pixels that may differ while still accepting the image.
-// 0 means no mismatches allowed. 1 means all mismatches allowed.
-func WithMatchTolerance(t float64) Option {
-	return &options{
-		matchTolerance: fptr(t),
-	}
-}
 
-func (s *options) apply(o *snapshotImageOptions) {
-	if s.pixelTolerance != nil {
-		o.pixelTolerance = *s.pixelTolerance
-	}
-
-	if s.matchTolerance != nil {
-		o.matchTolerance = *s.matchTolerance
-	}
-}
-
-func fptr(f float64) *float64 {
-	return &f
-}
+	"github.com/akabio/expect/internal/imageext"
+)
 
 // ToBeSnapshotImage saves the image in the first run, in later runs, compares the image to the saved one.
-// If they are not the same it will write a .current.pn and .diff.png version of the image.
+// If they are not the same it will write a .current and .diff version of the image next to it, like
+// .current.png and .diff.png. The diff shows the snapshot faded with the differences highlighted.
+// Snapshots are stored as .png, .bmp or .tiff depending on the extension of the path.
 // The images match by default when 99% of the pixels colors are by less than 10% off.
 // The Parameter SnapshotImageOptionExact forces the images to be exactly the same.
 func (e Val) ToBeSnapshotImage(path string, opts ...Option) Val {
 	e.t.Helper()
 
-	if !strings.HasSuffix(path, ".png") {
-		e.t.Fatalf("only png format is supported, pleas add a .png extension to the snapshot path")
+	if e.negate {
+		e.t.Fatalf("ToBeSnapshotImage can not be negated")
 	}
 
-	optOb := &snapshotImageOptions{
-		pixelTolerance: 0.1,
-		matchTolerance: 0.01,
+	if imageExtension(path) == "" {
+		e.t.Fatalf("unsupported image snapshot format %v, use one of %v", filepath.Ext(path), strings.Join(imageext.Extensions, ", "))
 	}
 
-	for _, opt := range opts {
-		opt.apply(optOb)
-	}
+	optOb := newSnapshotOptions(opts)
 
 	folder := filepath.Dir(path)
 	if folder != "." {
@@ -90,6 +37,9 @@ func (e Val) ToBeSnapshotImage(path string, opts ...Option) Val {
 		}
 	}
 
+	useSnapshot(path)
+	e.ex.resetReport()
+
 	existing, err := os.ReadFile(path)
 	if err != nil && !os.IsNotExist(err) {
 		e.t.Fatalf("failed to read snaphsot %v: %v", path, err)
@@ -112,16 +62,9 @@ func (e Val) ToBeSnapshotImage(path string, opts ...Option) Val {
 
 	// snapshot does not exist, create it
 	if existing == nil {
-		encoded := bytes.NewBuffer(nil)
-
-		err = png.Encode(encoded, img)
+		err = writeImage(path, img, optOb)
 		if err != nil {
-			e.t.Fatalf("failed encode snapshot image %v", path)
-		}
-
-		err = os.WriteFile(path, encoded.Bytes(), 0o644)
-		if err != nil {
-			e.t.Fatalf("failed to write snapshot %v", path)
+			e.t.Fatalf("failed to write snapshot %v, %v", path, err)
 		}
 
 		return e
@@ -134,95 +77,90 @@ func (e Val) ToBeSnapshotImage(path string, opts ...Option) Val {
 		e.t.Fatalf("failed to read snapshot %v", err)
 	}
 
-	isSame, diffImg := isSameImage(e.t, snapshotImage, img, optOb)
+	isSame, msg, diffImg := isSameImage(snapshotImage, img, optOb)
 	if isSame {
 		// all is well, snapshot is matched, remove a possible current version
-		os.RemoveAll(currentPath(path))
-		os.RemoveAll(diffPath(path))
+		removeImageArtifacts(path)
 		return e
 	}
 
-	// not the same image, write current output
-	current := bytes.NewBuffer(nil)
-	err = png.Encode(current, img)
-	if err != nil {
-		e.t.Fatalf("failed encode snapshot image %v", currentPath(path))
+	if e.ex.updateSnapshots() {
+		err = writeImage(path, img, optOb)
+		if err != nil {
+			e.t.Fatalf("failed to write snapshot %v, %v", path, err)
+		}
+
+		removeImageArtifacts(path)
+		e.logf("updated snapshot %v", path)
+
+		return e
 	}
 
-	err = os.WriteFile(currentPath(path), current.Bytes(), 0o644)
+	e.t.Error(msg)
+	e.reportImage(path, msg, snapshotImage, img, diffImg)
+
+	// not the same image, write current output
+	err = writeImage(currentPath(path), img, optOb)
 	if err != nil {
-		e.t.Fatalf("failed to write snapshot %v", currentPath(path))
+		e.t.Fatalf("failed to write snapshot %v, %v", currentPath(path), err)
 	}
 
+	writeArtifact(currentPath(path))
+
 	if diffImg != nil {
-		diff := bytes.NewBuffer(nil)
-		err = png.Encode(diff, diffImg)
+		err = writeImage(diffPath(path), diffImg, optOb)
 		if err != nil {
-			e.t.Fatalf("failed encode diff image %v", diffPath(path))
+			e.t.Fatalf("failed to write diff image %v, %v", diffPath(path), err)
 		}
 
-		err = os.WriteFile(diffPath(path), diff.Bytes(), 0o644)
+		writeArtifact(diffPath(path))
+	}
+
+	if diffImg != nil && optOb.compareImage {
+		err = writeImage(comparePath(path), compareImage(snapshotImage, img, diffImg), optOb)
 		if err != nil {
-			e.t.Fatalf("failed to diff snapshot %v", diffPath(path))
+			e.t.Fatalf("failed to write compare image %v, %v", comparePath(path), err)
 		}
+
+		writeArtifact(comparePath(path))
 	}
 
 	return e
 }
 
-func currentPath(i string) string {
-	return strings.TrimSuffix(i, ".png") + ".current.png"
-}
-
-func diffPath(i string) string {
-	return strings.TrimSuffix(i, ".png") + ".diff.png"
-}
+// writeImage encodes the image in the format of the path extension and writes it to path.
+func writeImage(path string, img image.Image, opts *snapshotOptions) error {
+	encoded := bytes.NewBuffer(nil)
 
-func isSameImage(t Test, snapshot, current image.Image, opts *snapshotImageOptions) (bool, image.Image) {
-	snapshotSize := snapshot.Bounds().Size()
-	currentSize := current.Bounds().Size()
-	if snapshotSize != currentSize {
-		t.Errorf("expected image size to be %v but it is %v", snapshotSize, currentSize)
-		return false, nil
+	err := imageEncoders[imageExtension(path)](encoded, img, opts)
+	if err != nil {
+		return err
 	}
 
-	diffImg := image.NewRGBA(snapshot.Bounds())
-
-	mismatches := 0
-
-	for y := 0; y < snapshotSize.Y; y++ {
-		for x := 0; x < snapshotSize.X; x++ {
-			rs, gs, bs, as := snapshot.At(x, y).RGBA()
-			rc, gc, bc, ac := current.At(x, y).RGBA()
-
-			rd := getDiffFor(rs, rc)
-			gd := getDiffFor(gs, gc)
-			bd := getDiffFor(bs, bc)
-			ad := getDiffFor(as, ac)
-
-			rb := math.Min(255, rd*3*255)
-			gb := math.Min(255, gd*3*255)
-			bb := math.Min(255, bd*3*255)
-
-			diffImg.SetRGBA(x, y, color.RGBA{R: uint8(rb), G: uint8(gb), B: uint8(bb), A: 255})
+	return os.WriteFile(path, encoded.Bytes(), 0o644)
+}
 
-			avg := (rd + gd + bd + ad) / 4
-			if avg > opts.pixelTolerance {
-				mismatches++
-			}
-		}
-	}
+// artifactPath inserts the kind of the artifact before the image extension.
+func artifactPath(path, kind string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + kind + ext
+}
 
-	m := float64(mismatches) / float64(snapshotSize.X*snapshotSize.Y)
+func currentPath(i string) string {
+	return artifactPath(i, ".current")
+}
 
-	if m > opts.matchTolerance {
-		t.Errorf("expected image does not match snapshot, %.1f%% of pixels do not match", m*100)
-		return false, diffImg
-	}
+func diffPath(i string) string {
+	return artifactPath(i, ".diff")
+}
 
-	return true, nil
+func comparePath(i string) string {
+	return artifactPath(i, ".compare")
 }
 
-func getDiffFor(rs, rc uint32) float64 {
-	return math.Abs(float64(rs)-float64(rc)) / (256*256 - 1)
+// removeImageArtifacts removes the files written for a mismatching image snapshot.
+func removeImageArtifacts(path string) {
+	os.RemoveAll(currentPath(path))
+	os.RemoveAll(diffPath(path))
+	os.RemoveAll(comparePath(path))
 }
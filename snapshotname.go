@@ -0,0 +1,105 @@
+package expect
+
+import (
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultSnapshotRoot is the folder used for automatically named snapshots when
+// Expect.SnapshotRoot is not set.
+const DefaultSnapshotRoot = "testdata/snapshots"
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+var (
+	snapshotCountersLock sync.Mutex
+	snapshotCounters     = map[string]int{}
+)
+
+// ToMatchSnapshot works like ToBeSnapshot but derives the snapshot path from the test name.
+// The n-th call in a test is stored in <root>/<test name>-<n>.snap, when a format is set
+// the extension of the format is used instead of .snap.
+func (e Val) ToMatchSnapshot(opts ...Option) Val {
+	e.t.Helper()
+
+	ext := ".snap"
+
+	format := newSnapshotOptions(opts).format
+	if format == nil {
+		format = e.ex.SnapshotFormat
+	}
+
+	if format != nil {
+		ext = formatExtension(format)
+	}
+
+	return e.ToBeSnapshot(e.snapshotPath(ext), opts...)
+}
+
+// ToMatchSnapshotImage works like ToBeSnapshotImage but derives the snapshot path from the test name.
+// The n-th call in a test is stored in <root>/<test name>-<n>.png.
+func (e Val) ToMatchSnapshotImage(opts ...Option) Val {
+	e.t.Helper()
+	return e.ToBeSnapshotImage(e.snapshotPath(".png"), opts...)
+}
+
+// snapshotPath creates a path from the tests name and a counter for the snapshot calls
+// within that test.
+func (e Val) snapshotPath(ext string) string {
+	e.t.Helper()
+
+	named, is := e.t.(interface{ Name() string })
+	if !is {
+		e.t.Fatalf("automatic snapshot names need a test with a Name() method, use ToBeSnapshot instead")
+		return ""
+	}
+
+	name := named.Name()
+
+	snapshotCountersLock.Lock()
+	snapshotCounters[name]++
+	count := snapshotCounters[name]
+	snapshotCountersLock.Unlock()
+
+	// reset the counter when the test finishes so repeated runs use the same files
+	if count == 1 {
+		if c, is := e.t.(interface{ Cleanup(func()) }); is {
+			c.Cleanup(func() {
+				snapshotCountersLock.Lock()
+				delete(snapshotCounters, name)
+				snapshotCountersLock.Unlock()
+			})
+		}
+	}
+
+	segments := strings.Split(name, "/")
+	for i, s := range segments {
+		segments[i] = sanitizeFileName(s)
+	}
+
+	prefix := filepath.Join(e.ex.snapshotRoot(), filepath.Join(segments...))
+	runNamedTest(prefix)
+
+	return prefix + "-" + strconv.Itoa(count) + ext
+}
+
+func (e *Expect) snapshotRoot() string {
+	if e.SnapshotRoot == "" {
+		return DefaultSnapshotRoot
+	}
+
+	return e.SnapshotRoot
+}
+
+// sanitizeFileName replaces all characters which are not safe to use in a file name.
+func sanitizeFileName(n string) string {
+	n = unsafeFileChars.ReplaceAllString(n, "_")
+	if n == "" || n == "." || n == ".." {
+		return "_"
+	}
+
+	return n
+}
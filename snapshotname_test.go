@@ -0,0 +1,48 @@
+package expect_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/akabio/expect"
+	"github.com/akabio/expect/internal/test"
+)
+
+func TestMatchSnapshotNamedByTest(t *testing.T) {
+	cleanTestData(t)
+
+	ex := &expect.Expect{SnapshotRoot: "testdata/volatile"}
+	ex.Value(t, "first", "a").ToMatchSnapshot()
+	ex.Value(t, "second", "b").ToMatchSnapshot()
+
+	t.Run("sub case ä", func(t *testing.T) {
+		ex.Value(t, "sub", "c").ToMatchSnapshot()
+	})
+
+	for file, content := range map[string]string{
+		"testdata/volatile/TestMatchSnapshotNamedByTest-1.snap":            "a",
+		"testdata/volatile/TestMatchSnapshotNamedByTest-2.snap":            "b",
+		"testdata/volatile/TestMatchSnapshotNamedByTest/sub_case__-1.snap": "c",
+	} {
+		data, err := os.ReadFile(file)
+		expect.Error(t, err).ToBe(nil)
+		expect.Value(t, file, string(data)).ToBe(content)
+	}
+}
+
+func TestMatchSnapshotImageNamedByTest(t *testing.T) {
+	cleanTestData(t)
+
+	ex := &expect.Expect{SnapshotRoot: "testdata/volatile"}
+	ex.Value(t, "image", sampleImage).ToMatchSnapshotImage()
+
+	_, err := os.Stat("testdata/volatile/TestMatchSnapshotImageNamedByTest-1.png")
+	expect.Error(t, err).ToBe(nil)
+}
+
+func TestErrorMatchSnapshotWithoutName(t *testing.T) {
+	l := test.New(t, func(t expect.Test) {
+		expect.Value(t, "content", "x").ToMatchSnapshot()
+	})
+	l.ExpectMessage(0).ToBe("automatic snapshot names need a test with a Name() method, use ToBeSnapshot instead")
+}
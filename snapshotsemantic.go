@@ -0,0 +1,262 @@
+package expect
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// semanticResult is the outcome of comparing parsed JSON or YAML snapshots.
+type semanticResult struct {
+	diffs []difference
+	more  int
+	// canonical is the pretty printed current output
+	canonical []byte
+}
+
+// usesSemanticCompare returns true if the snapshot should be compared structurally, either enabled
+// by option or for .json, .yaml and .yml snapshots.
+func usesSemanticCompare(path string, opts *snapshotOptions) bool {
+	if opts.semantic != nil {
+		return *opts.semantic
+	}
+
+	f := formatForPath(path)
+
+	return f == JSONFormat || f == YAMLFormat
+}
+
+// semanticCompare parses both snapshots as JSON or YAML and compares the parsed values. Returns
+// false when one of them can not be parsed.
+func semanticCompare(path string, existing, current []byte, max int) (*semanticResult, bool) {
+	x, err := parseSemantic(existing)
+	if err != nil {
+		return nil, false
+	}
+
+	v, err := parseSemantic(current)
+	if err != nil {
+		return nil, false
+	}
+
+	// JSON is pretty printed, YAML is already written by the YAML format
+	res := &semanticResult{canonical: current}
+
+	if formatForPath(path) == JSONFormat {
+		res.canonical, err = marshalJSON(v)
+		if err != nil {
+			return nil, false
+		}
+	}
+
+	if !reflect.DeepEqual(x, v) {
+		res.diffs, res.more = compareJSON(x, v, max)
+	}
+
+	return res, true
+}
+
+// parseSemantic parses JSON or YAML into generic values. Numbers are parsed from their literal
+// text into a json.Number with a canonical decimal, they are never rounded to float64.
+func parseSemantic(data []byte) (any, error) {
+	if json.Valid(data) {
+		var v any
+
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+
+		err := dec.Decode(&v)
+		if err != nil {
+			return nil, err
+		}
+
+		return normalizeNumbers(v)
+	}
+
+	var doc yaml.Node
+
+	err := yaml.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return yamlValue(&doc)
+}
+
+// normalizeNumbers replaces all numbers by their canonical decimal, so 1.0 and 1 are equal.
+func normalizeNumbers(v any) (any, error) {
+	var err error
+
+	switch t := v.(type) {
+	case map[string]any:
+		for k, e := range t {
+			t[k], err = normalizeNumbers(e)
+			if err != nil {
+				return nil, err
+			}
+		}
+	case []any:
+		for i, e := range t {
+			t[i], err = normalizeNumbers(e)
+			if err != nil {
+				return nil, err
+			}
+		}
+	case json.Number:
+		return canonicalNumber(string(t))
+	}
+
+	return v, nil
+}
+
+// yamlValue converts a YAML node into the generic values of encoding/json. Scalars are converted
+// from their literal text.
+func yamlValue(n *yaml.Node) (any, error) {
+	switch n.Kind {
+	case 0:
+		// empty document
+		return nil, nil
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil, nil
+		}
+
+		return yamlValue(n.Content[0])
+	case yaml.AliasNode:
+		return yamlValue(n.Alias)
+	case yaml.MappingNode:
+		m := map[string]any{}
+
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			v, err := yamlValue(n.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+
+			m[n.Content[i].Value] = v
+		}
+
+		return m, nil
+	case yaml.SequenceNode:
+		l := make([]any, 0, len(n.Content))
+
+		for _, c := range n.Content {
+			v, err := yamlValue(c)
+			if err != nil {
+				return nil, err
+			}
+
+			l = append(l, v)
+		}
+
+		return l, nil
+	}
+
+	switch n.ShortTag() {
+	case "!!null":
+		return nil, nil
+	case "!!bool":
+		var b bool
+		err := n.Decode(&b)
+
+		return b, err
+	case "!!int":
+		literal := strings.ReplaceAll(strings.TrimPrefix(n.Value, "+"), "_", "")
+
+		base := 10
+		if l := strings.ToLower(strings.TrimPrefix(literal, "-")); strings.HasPrefix(l, "0x") ||
+			strings.HasPrefix(l, "0o") || strings.HasPrefix(l, "0b") {
+			base = 0
+		}
+
+		i, ok := new(big.Int).SetString(literal, base)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %v", n.Value)
+		}
+
+		return json.Number(i.String()), nil
+	case "!!float":
+		c, err := canonicalNumber(strings.TrimPrefix(n.Value, "+"))
+		if err == nil {
+			return c, nil
+		}
+
+		// .inf and .nan have no decimal
+		var f float64
+		err = n.Decode(&f)
+
+		return f, err
+	}
+
+	return n.Value, nil
+}
+
+// canonicalNumber returns the shortest exact decimal of a number literal, so 1.0, 1 and 1e0 are
+// the same. Numbers with many leading or trailing zeros are kept in exponent notation.
+func canonicalNumber(literal string) (json.Number, error) {
+	invalid := fmt.Errorf("invalid number %v", literal)
+
+	n := literal
+	neg := strings.HasPrefix(n, "-")
+	n = strings.TrimPrefix(n, "-")
+
+	mantissa, exp := n, 0
+
+	if i := strings.IndexAny(n, "eE"); i >= 0 {
+		e, err := strconv.Atoi(strings.TrimPrefix(n[i+1:], "+"))
+		if err != nil {
+			return "", invalid
+		}
+
+		mantissa, exp = n[:i], e
+	}
+
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		exp -= len(mantissa) - i - 1
+		mantissa = mantissa[:i] + mantissa[i+1:]
+	}
+
+	if mantissa == "" || strings.Trim(mantissa, "0123456789") != "" {
+		return "", invalid
+	}
+
+	// the value is digits * 10^exp
+	digits := strings.TrimRight(strings.TrimLeft(mantissa, "0"), "0")
+	if digits == "" {
+		return "0", nil
+	}
+
+	exp += len(strings.TrimLeft(mantissa, "0")) - len(digits)
+
+	const maxZeros = 21
+
+	var s string
+
+	switch {
+	case exp >= 0 && exp <= maxZeros:
+		s = digits + strings.Repeat("0", exp)
+	case exp < 0 && -exp < len(digits):
+		s = digits[:len(digits)+exp] + "." + digits[len(digits)+exp:]
+	case exp < 0 && -exp-len(digits) <= maxZeros:
+		s = "0." + strings.Repeat("0", -exp-len(digits)) + digits
+	default:
+		s = digits + "e" + strconv.Itoa(exp)
+	}
+
+	if neg {
+		s = "-" + s
+	}
+
+	return json.Number(s), nil
+}
+
+// message lists the differences by path.
+func (s *semanticResult) message(path string) string {
+	return formatDifferences("snapshot "+path, s.diffs, s.more)
+}
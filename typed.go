@@ -0,0 +1,153 @@
+package expect
+
+import "golang.org/x/exp/constraints"
+
+// TypedVal is a value of type T. Its expectations only accept values of the same type so
+// mismatching types are reported by the compiler. The untyped expectations of the embedded
+// Val are available as well.
+type TypedVal[T any] struct {
+	Val
+}
+
+// That wraps a value of type T and provides type checked expectations for it.
+// It delegates to the default instance `Default`.
+func That[T any](t Test, name string, val T) TypedVal[T] {
+	return ThatWith(Default, t, name, val)
+}
+
+// ThatWith works like That but uses the given instance, generic methods are not supported
+// so it's not a method of Expect.
+func ThatWith[T any](ex *Expect, t Test, name string, val T) TypedVal[T] {
+	return TypedVal[T]{ex.Value(t, name, val)}
+}
+
+// Not returns a negated value, all expectations called on it must not be met.
+func (e TypedVal[T]) Not() TypedVal[T] {
+	return TypedVal[T]{e.Val.Not()}
+}
+
+// ToBe asserts that the value is deeply equal to the expected value.
+func (e TypedVal[T]) ToBe(expected T) TypedVal[T] {
+	e.t.Helper()
+	e.check(e.toBe(expected))
+
+	return e
+}
+
+// NotToBe asserts that the value is not deeply equal to the unexpected value.
+func (e TypedVal[T]) NotToBe(unExpected T) TypedVal[T] {
+	e.t.Helper()
+	e.Not().check(e.deepEqual(unExpected))
+
+	return e
+}
+
+// SliceVal is a slice with elements of type E.
+type SliceVal[E any] struct {
+	TypedVal[[]E]
+}
+
+// ThatSlice wraps a slice and provides type checked expectations for it and its elements.
+// It delegates to the default instance `Default`.
+func ThatSlice[E any](t Test, name string, val []E) SliceVal[E] {
+	return ThatSliceWith(Default, t, name, val)
+}
+
+// ThatSliceWith works like ThatSlice but uses the given instance.
+func ThatSliceWith[E any](ex *Expect, t Test, name string, val []E) SliceVal[E] {
+	return SliceVal[E]{ThatWith(ex, t, name, val)}
+}
+
+// Not returns a negated value, all expectations called on it must not be met.
+func (e SliceVal[E]) Not() SliceVal[E] {
+	return SliceVal[E]{e.TypedVal.Not()}
+}
+
+// ToContain asserts that the slice contains the element.
+func (e SliceVal[E]) ToContain(element E) SliceVal[E] {
+	e.t.Helper()
+	e.check(e.toContain(element))
+
+	return e
+}
+
+// ToCount asserts that the slice has c elements.
+func (e SliceVal[E]) ToCount(c int) SliceVal[E] {
+	e.t.Helper()
+	e.check(e.toCount(c))
+
+	return e
+}
+
+// First returns the first element of the slice.
+func (e SliceVal[E]) First() TypedVal[E] {
+	e.t.Helper()
+	return TypedVal[E]{e.Val.First()}
+}
+
+// Last returns the last element of the slice.
+func (e SliceVal[E]) Last() TypedVal[E] {
+	e.t.Helper()
+	return TypedVal[E]{e.Val.Last()}
+}
+
+// OrderedVal is a value which can be compared with <, <=, > and >=.
+type OrderedVal[T constraints.Ordered] struct {
+	TypedVal[T]
+}
+
+// ThatOrdered wraps a number or string and provides type checked expectations including
+// ordered comparisons. It delegates to the default instance `Default`.
+func ThatOrdered[T constraints.Ordered](t Test, name string, val T) OrderedVal[T] {
+	return ThatOrderedWith(Default, t, name, val)
+}
+
+// ThatOrderedWith works like ThatOrdered but uses the given instance.
+func ThatOrderedWith[T constraints.Ordered](ex *Expect, t Test, name string, val T) OrderedVal[T] {
+	return OrderedVal[T]{ThatWith(ex, t, name, val)}
+}
+
+// Not returns a negated value, all expectations called on it must not be met.
+func (e OrderedVal[T]) Not() OrderedVal[T] {
+	return OrderedVal[T]{e.TypedVal.Not()}
+}
+
+// ToBeGreaterThan asserts that the value is greater than x.
+func (e OrderedVal[T]) ToBeGreaterThan(x T) OrderedVal[T] {
+	e.t.Helper()
+	e.check(e.toBeGreaterThan(x))
+
+	return e
+}
+
+// ToBeGreaterOrEqual asserts that the value is greater than or equal to x.
+func (e OrderedVal[T]) ToBeGreaterOrEqual(x T) OrderedVal[T] {
+	e.t.Helper()
+	e.check(e.toBeGreaterOrEqual(x))
+
+	return e
+}
+
+// ToBeLessThan asserts that the value is less than x.
+func (e OrderedVal[T]) ToBeLessThan(x T) OrderedVal[T] {
+	e.t.Helper()
+	e.check(e.toBeLessThan(x))
+
+	return e
+}
+
+// ToBeLessOrEqual asserts that the value is less than or equal to x.
+func (e OrderedVal[T]) ToBeLessOrEqual(x T) OrderedVal[T] {
+	e.t.Helper()
+	e.check(e.toBeLessOrEqual(x))
+
+	return e
+}
+
+// ToBeBetween asserts that the value is between lo and hi, both bounds are included.
+func (e OrderedVal[T]) ToBeBetween(lo, hi T) OrderedVal[T] {
+	e.t.Helper()
+	e.check(e.toBeBetween(lo, hi))
+
+	return e
+}
@@ -0,0 +1,79 @@
+package expect_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akabio/expect"
+	"github.com/akabio/expect/internal/test"
+)
+
+func TestThat(t *testing.T) {
+	expect.That(t, "number", int64(3)).ToBe(3)
+	expect.That(t, "number", int64(3)).NotToBe(4)
+	expect.That(t, "number", int64(3)).Not().ToBe(4)
+	expect.That(t, "duration", time.Second).ToBe(1000 * time.Millisecond)
+	expect.That(t, "point", struct{ X, Y int }{1, 2}).ToBe(struct{ X, Y int }{1, 2})
+
+	// the untyped expectations are available as well
+	expect.That(t, "name", "gopher").ToHavePrefix("go").ToCount(6)
+}
+
+func TestThatFail(t *testing.T) {
+	l := test.New(t, func(t expect.Test) {
+		expect.That(t, "number", int64(3)).ToBe(4)
+		expect.That(t, "number", int64(3)).NotToBe(3)
+	})
+	l.ExpectMessages().ToCount(2)
+	l.ExpectMessage(0).ToBe("expected number to be 4 but it is 3")
+	l.ExpectMessage(1).ToBe("expected number to NOT be 3 but it is")
+}
+
+func TestThatSlice(t *testing.T) {
+	expect.ThatSlice(t, "list", []int64{1, 2, 3}).ToContain(2).ToCount(3)
+	expect.ThatSlice(t, "list", []int64{1, 2, 3}).Not().ToContain(4)
+	expect.ThatSlice(t, "list", []int64{1, 2, 3}).First().ToBe(1)
+	expect.ThatSlice(t, "list", []string{"a", "b"}).Last().ToBe("b")
+
+	l := test.New(t, func(t expect.Test) {
+		expect.ThatSlice(t, "list", []int64{1, 2, 3}).ToContain(4)
+		expect.ThatSlice(t, "list", []int64{1, 2, 3}).Last().ToBe(2)
+	})
+	l.ExpectMessages().ToCount(2)
+	l.ExpectMessage(0).ToBe("expected 4 to be in list [1,2,3] but it is not")
+	l.ExpectMessage(1).ToBe("expected element at index 2 of list to be 2 but it is 3")
+}
+
+func TestThatOrdered(t *testing.T) {
+	expect.ThatOrdered(t, "count", 5).ToBeGreaterThan(4).ToBeGreaterOrEqual(5).ToBeLessThan(6).ToBeLessOrEqual(5)
+	expect.ThatOrdered(t, "name", "b").ToBeGreaterThan("a").Not().ToBeGreaterThan("c")
+	expect.ThatOrdered(t, "liters", 1.5).ToBe(1.5)
+
+	l := test.New(t, func(t expect.Test) {
+		expect.ThatOrdered(t, "count", 5).ToBeGreaterThan(5)
+		expect.ThatOrdered(t, "count", 5).ToBeLessOrEqual(4)
+		expect.ThatOrdered(t, "name", "b").Not().ToBeLessThan("c")
+	})
+	l.ExpectMessages().ToCount(3)
+	l.ExpectMessage(0).ToBe("expected count to be greater than 5 but it is 5")
+	l.ExpectMessage(1).ToBe("expected count to be less than or equal to 4 but it is 5")
+	l.ExpectMessage(2).ToBe("expected name NOT to be less than 'c' but it is 'b'")
+}
+
+func TestThatWith(t *testing.T) {
+	ex := &expect.Expect{MaxDifferences: 1}
+
+	expect.ThatWith(ex, t, "number", int64(3)).ToBe(3)
+	expect.ThatSliceWith(ex, t, "list", []int{1, 2}).ToContain(2).First().ToBe(1)
+	expect.ThatOrderedWith(ex, t, "count", 5).ToBeBetween(4, 6)
+
+	l := test.New(t, func(t expect.Test) {
+		expect.ThatWith(ex, t, "list", []int{1, 2}).ToBe([]int{3, 4})
+		expect.ThatSliceWith(ex, t, "list", []int{1, 2}).ToBe([]int{3, 4})
+	})
+	l.ExpectMessages().ToCount(2)
+	l.ExpectMessage(0).ToBe(`expected list to be equal but it has 2 differences
+    list[0]: expected 3 but it is 1
+    ... and 1 more`)
+	l.ExpectMessage(1).ToBe(l.Messages[0])
+}
@@ -0,0 +1,53 @@
+package expect
+
+import (
+	"flag"
+	"os"
+	"strconv"
+	"testing"
+)
+
+// UpdateSnapshotsEnv is the environment variable which enables the snapshot update mode
+// when set to a true value like 1 or true.
+const UpdateSnapshotsEnv = "EXPECT_UPDATE_SNAPSHOTS"
+
+// UpdateSnapshotsFlag is the name of the test flag which enables the snapshot update mode.
+// It's only defined in test binaries and only if no other package defined it before.
+const UpdateSnapshotsFlag = "expect.update"
+
+func init() {
+	// the test main parses the flags before any test runs, so the flag can't be defined later
+	if testing.Testing() && flag.Lookup(UpdateSnapshotsFlag) == nil {
+		flag.Bool(UpdateSnapshotsFlag, false, "overwrite snapshots which do not match the current output")
+	}
+}
+
+// updateSnapshots returns true if mismatching snapshots should be overwritten. It's enabled
+// by the UpdateSnapshots field, the environment variable EXPECT_UPDATE_SNAPSHOTS
+// or the test flag -expect.update.
+func (e *Expect) updateSnapshots() bool {
+	if e.UpdateSnapshots {
+		return true
+	}
+
+	if f := flag.Lookup(UpdateSnapshotsFlag); f != nil {
+		if update, _ := strconv.ParseBool(f.Value.String()); update {
+			return true
+		}
+	}
+
+	update, _ := strconv.ParseBool(os.Getenv(UpdateSnapshotsEnv))
+
+	return update
+}
+
+// logf logs the message if the test supports logging.
+func (e Val) logf(f string, i ...interface{}) {
+	e.t.Helper()
+
+	if l, is := e.t.(interface {
+		Logf(f string, i ...interface{})
+	}); is {
+		l.Logf(f, i...)
+	}
+}